@@ -1,55 +1,118 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
 	"github.com/sandepten/work-obsidian-noter/internal/ui"
 	"github.com/spf13/cobra"
 )
 
+var (
+	addDate string
+	addYes  bool
+	addTop  bool
+)
+
 var addCmd = &cobra.Command{
-	Use:   "add [task description]",
-	Short: "Add a new pending work item",
-	Long:  `Add a new pending work item to today's note.`,
-	Args:  cobra.MinimumNArgs(1),
-	RunE:  runAdd,
+	Use:     "add [task description]",
+	Aliases: []string{"a"},
+	Short:   "Add a new pending work item",
+	Long: `Add a new pending work item to today's note, or to another day's note
+with --date. Creating a note for a day other than today asks for
+confirmation first; pass --yes to skip it.
+
+New items go at the bottom of the pending list, or at the top with --top
+or ADD_POSITION=top.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runAdd,
 }
 
 func init() {
+	addCmd.Flags().StringVarP(&addDate, "date", "d", "", "Add to the note for this date (YYYY-MM-DD, default today)")
+	addCmd.Flags().BoolVarP(&addYes, "yes", "y", false, "Create a note for another date without confirmation")
+	addCmd.Flags().BoolVar(&addTop, "top", false, "Add the item at the top of the pending list")
+	addForceFlag(addCmd)
 	rootCmd.AddCommand(addCmd)
 }
 
 func runAdd(cmd *cobra.Command, args []string) error {
 	today := time.Now().Truncate(24 * time.Hour)
-	taskText := strings.Join(args, " ")
+	taskText := strings.TrimSpace(strings.Join(args, " "))
+	if taskText == "" {
+		return fmt.Errorf("task description cannot be empty")
+	}
 
-	// Get or create today's note
-	todayNote, err := parser.FindTodayNote(today)
-	if err != nil {
-		return fmt.Errorf("error finding today's note: %w", err)
+	date := today
+	if addDate != "" {
+		var err error
+		if date, err = parseDate(addDate); err != nil {
+			return err
+		}
 	}
 
-	if todayNote == nil {
-		todayNote = writer.CreateTodayNote(today)
-		fmt.Println(ui.InfoStyle.Render("Creating today's note..."))
+	// Get or create the note
+	todayNote, err := parser.FindTodayNote(date)
+	if err != nil && !errors.Is(err, notes.ErrNoteNotFound) {
+		return fmt.Errorf("error finding note: %w", err)
+	}
+
+	if todayNote != nil {
+		if err := checkNotFinalized(todayNote); err != nil {
+			return err
+		}
+	} else {
+		// A note for another day is often a typo in --date, so check first
+		if !date.Equal(today) && !addYes {
+			confirmed, err := prompter.ConfirmAction(fmt.Sprintf("No note exists for %s (%s). Create it", date.Format("Mon, Jan 2, 2006"), ui.RelativeDate(date)))
+			if err != nil {
+				return fmt.Errorf("error confirming note creation: %w", err)
+			}
+			if !confirmed {
+				fmt.Println(ui.MutedStyle.Render("No note created."))
+				return nil
+			}
+		}
+
+		todayNote, err = createNote(date)
+		if err != nil {
+			return fmt.Errorf("error creating note: %w", err)
+		}
+		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("Creating new note for %s on %s...", cfg.WorkplaceName, date.Format("Mon, Jan 2, 2006"))))
 	}
 
 	// Add the new item
-	todayNote.AddPendingItem(taskText)
+	top := addAtTop()
+	if top {
+		todayNote.PrependPendingItem(taskText)
+	} else {
+		todayNote.AddPendingItem(taskText)
+	}
 
 	// Save the note
-	if err := writer.WriteNote(todayNote); err != nil {
+	if err := saveNote(todayNote); err != nil {
 		return fmt.Errorf("error saving note: %w", err)
 	}
 
 	fmt.Println()
 	fmt.Println(ui.RenderSuccess("Task added successfully!"))
-	fmt.Println(ui.RenderPendingItem(len(todayNote.PendingWork), taskText))
+	index := len(todayNote.PendingWork) - 1
+	if top {
+		index = 0
+	}
+	fmt.Println(ui.RenderPendingItem(prompter.DisplayNumber(todayNote.PendingWork, index), taskText))
 	fmt.Println()
 	fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("  📋 You now have %d pending task(s)", len(todayNote.PendingWork))))
 	fmt.Println()
 
 	return nil
 }
+
+// addAtTop reports whether new tasks go at the top of the pending list, with
+// --top or ADD_POSITION=top
+func addAtTop() bool {
+	return addTop || strings.EqualFold(strings.TrimSpace(cfg.AddPosition), "top")
+}
@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
 	"github.com/sandepten/work-obsidian-noter/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -13,11 +17,20 @@ var addManyCmd = &cobra.Command{
 	Short: "Add multiple work items interactively",
 	Long: `Add multiple pending work items in a loop.
 Press Enter after each task to add it.
-Press Ctrl+C when done to exit and see a summary.`,
+Press Ctrl+C when done to exit and see a summary.
+
+Each change is saved to today's note as soon as it is entered, so tasks
+are not lost if the terminal closes unexpectedly. Tasks go at the bottom of
+the pending list, or the top with ADD_POSITION=top.
+
+Meta-commands typed at the prompt:
+  :undo   remove the last added task
+  :done   mark the last added task as completed`,
 	RunE: runAddMany,
 }
 
 func init() {
+	addForceFlag(addManyCmd)
 	rootCmd.AddCommand(addManyCmd)
 }
 
@@ -25,25 +38,40 @@ func runAddMany(cmd *cobra.Command, args []string) error {
 	today := time.Now().Truncate(24 * time.Hour)
 
 	// Get or create today's note
+	created := false
 	todayNote, err := parser.FindTodayNote(today)
-	if err != nil {
+	if err != nil && !errors.Is(err, notes.ErrNoteNotFound) {
 		return fmt.Errorf("error finding today's note: %w", err)
 	}
 
-	if todayNote == nil {
-		todayNote = writer.CreateTodayNote(today)
+	if todayNote != nil {
+		if err := checkNotFinalized(todayNote); err != nil {
+			return err
+		}
+	} else {
+		todayNote, err = createNote(today)
+		if err != nil {
+			return fmt.Errorf("error creating today's note: %w", err)
+		}
+		created = true
 		prompter.DisplayMessage("Creating today's note...")
 	}
 
 	// Display header
 	fmt.Println()
 	fmt.Println(ui.TitleStyle.Render("📝 Add Multiple Tasks"))
-	fmt.Println(ui.MutedStyle.Render("Enter each task and press Enter. Press Ctrl+C when done."))
+	fmt.Println(ui.MutedStyle.Render("Enter each task and press Enter. Type :undo or :done to fix the last one. Press Ctrl+C when done."))
 	fmt.Println(ui.RenderDivider(50))
 	fmt.Println()
 
-	var addedTasks []string
+	var added []addedTask
 	taskNumber := 1
+	top := addAtTop()
+
+	// In a terminal the list of added tasks is redrawn in place above the
+	// prompt; listLines tracks how many lines to move back up over
+	interactive := ui.IsTerminal(os.Stdin) && ui.IsTerminal(os.Stdout) && !ui.IsPlain()
+	listLines := 0
 
 	for {
 		task, interrupted, err := prompter.PromptForTaskInLoop(taskNumber)
@@ -59,37 +87,94 @@ func runAddMany(cmd *cobra.Command, args []string) error {
 		// Skip empty input
 		if task == "" {
 			fmt.Println(ui.MutedStyle.Render("  (empty input skipped)"))
+			listLines += 2
 			continue
 		}
 
-		// Add the task
-		todayNote.AddPendingItem(task)
-		addedTasks = append(addedTasks, task)
+		var message string
+		switch task {
+		case ":undo":
+			// Remove the last added item from whichever section it is in
+			if len(added) == 0 {
+				fmt.Println(ui.MutedStyle.Render("  (nothing to undo)"))
+				listLines += 2
+				continue
+			}
+			last := added[len(added)-1]
+			if last.done {
+				todayNote.RemoveCompletedItem(len(todayNote.CompletedWork) - 1)
+			} else {
+				todayNote.RemovePendingItem(last.index)
+			}
+			added = added[:len(added)-1]
+			taskNumber--
+			message = fmt.Sprintf("  %s Removed: %s", ui.IconSuccess, last.text)
+		case ":done":
+			// Mark the just-added item as completed
+			if len(added) == 0 || added[len(added)-1].done {
+				fmt.Println(ui.MutedStyle.Render("  (no pending task to mark done)"))
+				listLines += 2
+				continue
+			}
+			todayNote.MarkItemCompleted(added[len(added)-1].index)
+			added[len(added)-1].done = true
+			message = fmt.Sprintf("  %s Done: %s", ui.IconSuccess, added[len(added)-1].text)
+		default:
+			index := len(todayNote.PendingWork)
+			if top {
+				todayNote.PrependPendingItem(task)
+				index = 0
+			} else {
+				todayNote.AddPendingItem(task)
+			}
+			added = append(added, addedTask{text: task, index: index})
+			taskNumber++
+			message = fmt.Sprintf("  %s Added: %s", ui.IconSuccess, task)
+		}
+
+		// Save every change right away rather than only on exit
+		if _, _, err := writer.WriteNote(todayNote); err != nil {
+			return fmt.Errorf("error saving note: %w", err)
+		}
 
-		// Show confirmation
-		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("  %s Added: %s", ui.IconSuccess, task)))
-		taskNumber++
+		// Show confirmation, or redraw the running list over the previous one.
+		// Once the list no longer fits on screen the cursor can't move back
+		// over it, so fall back to printing each confirmation.
+		list := renderAddedTasks(added)
+		lines := strings.Count(list, "\n") + 1
+		if interactive {
+			height := ui.TerminalHeight(os.Stdout)
+			interactive = listLines+1 < height && lines+1 < height
+		}
+		if interactive {
+			// Move up over the previous list and the prompt line, then clear below
+			fmt.Printf("\033[%dA\033[J", listLines+1)
+			fmt.Println(list)
+			listLines = lines
+		} else {
+			fmt.Println(ui.SuccessStyle.Render(message))
+		}
 	}
 
 	fmt.Println()
 	fmt.Println(ui.RenderDivider(50))
 
-	// Save the note if any tasks were added
-	if len(addedTasks) > 0 {
-		if err := writer.WriteNote(todayNote); err != nil {
-			return fmt.Errorf("error saving note: %w", err)
-		}
+	// Tasks were saved as they were entered; list and commit the note once
+	if len(added) > 0 || created {
+		announceWrite(todayNote, todayNote.FilePath)
+	}
 
+	if len(added) > 0 {
 		// Show summary
 		fmt.Println()
-		summary := fmt.Sprintf("Added %d task(s) to today's worklog", len(addedTasks))
+		summary := fmt.Sprintf("Added %d task(s) to today's worklog", len(added))
 		fmt.Println(ui.RenderSuccess(summary))
 		fmt.Println()
 
 		// List added tasks
 		fmt.Println(ui.InfoStyle.Render("Tasks added:"))
-		for i, task := range addedTasks {
-			fmt.Println(ui.RenderPendingItem(i+1, task))
+		for i, task := range added {
+			fmt.Println(renderAddedTask(i+1, task))
 		}
 	} else {
 		fmt.Println()
@@ -99,3 +184,33 @@ func runAddMany(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	return nil
 }
+
+// addedTask is a task entered during an add-many session. index is its place
+// in the pending list, which stays valid while it is the latest task.
+type addedTask struct {
+	text  string
+	done  bool
+	index int
+}
+
+// renderAddedTask renders a single added task, checked off if it was marked done
+func renderAddedTask(index int, task addedTask) string {
+	if task.done {
+		return ui.RenderCompletedItem(index, task.text)
+	}
+	return ui.RenderPendingItem(index, task.text)
+}
+
+// renderAddedTasks renders the tasks added so far in this session as a card
+func renderAddedTasks(tasks []addedTask) string {
+	header := ui.HeaderStyle.Render("Added") + " " + ui.RenderBadge(len(tasks), ui.PendingBadgeStyle)
+	if len(tasks) == 0 {
+		return header + "\n" + ui.RenderEmptyState("  No tasks added yet")
+	}
+
+	var lines []string
+	for i, task := range tasks {
+		lines = append(lines, renderAddedTask(i+1, task))
+	}
+	return header + "\n" + ui.PendingCardStyle.Render(strings.Join(lines, "\n"))
+}
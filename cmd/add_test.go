@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddRejectsBlankDescription(t *testing.T) {
+	for _, args := range [][]string{{}, {""}, {"  ", "\t"}} {
+		err := runAdd(addCmd, args)
+		if err == nil || !strings.Contains(err.Error(), "task description cannot be empty") {
+			t.Errorf("runAdd(%q) error = %v, want empty description", args, err)
+		}
+	}
+}
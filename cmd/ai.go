@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
+	"github.com/sandepten/work-obsidian-noter/internal/summarizer"
+	"github.com/sandepten/work-obsidian-noter/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	aiProviderOverride string
+	aiModelOverride    string
+)
+
+// addAIOverrideFlags registers the per-run --provider and --model flags on a command
+func addAIOverrideFlags(c *cobra.Command) {
+	c.Flags().StringVar(&aiProviderOverride, "provider", "", "Override the AI provider for this run")
+	c.Flags().StringVar(&aiModelOverride, "model", "", "Override the AI model for this run")
+}
+
+// summarizerFor returns the AI client to use for a command, applying any
+// --provider/--model overrides. It returns nil when AI is disabled.
+func summarizerFor(c *cobra.Command) (*summarizer.Client, error) {
+	if aiClient == nil {
+		return nil, nil
+	}
+
+	providerChanged := c.Flags().Changed("provider")
+	modelChanged := c.Flags().Changed("model")
+	if !providerChanged && !modelChanged {
+		return aiClient, nil
+	}
+
+	provider := cfg.AIProvider
+	if providerChanged {
+		provider = strings.TrimSpace(aiProviderOverride)
+		if provider == "" {
+			return nil, fmt.Errorf("--provider cannot be empty")
+		}
+	}
+
+	model := cfg.AIModel
+	if modelChanged {
+		model = strings.TrimSpace(aiModelOverride)
+		if model == "" {
+			return nil, fmt.Errorf("--model cannot be empty")
+		}
+	}
+
+	return newAIClient(provider, model)
+}
+
+// newAIClient creates a summarizer client for the given provider and model,
+// applying the remaining AI settings from the config
+func newAIClient(provider, model string) (*summarizer.Client, error) {
+	client, err := summarizer.NewClient(cfg.OpenCodeServer, provider, model)
+	if err != nil {
+		return nil, err
+	}
+	client.SetHealthPath(cfg.AIHealthPath)
+	client.SetWaitTimeout(cfg.AIWait)
+	client.SetPollInterval(cfg.AIPollInterval)
+	client.SetPersona(cfg.AIPersona)
+	client.SetSystemPrompt(cfg.AISystemPrompt)
+	if cacheDir, err := os.UserCacheDir(); err == nil {
+		client.SetConnectionCache(filepath.Join(cacheDir, "worklog", "connection"))
+	}
+	return client, nil
+}
+
+// generateSummary displays an "AI Summary" section, summarizes the completed
+// items and shows the result. Connection and generation failures are shown as
+// warnings and result in an empty summary rather than an error.
+func generateSummary(ctx context.Context, client *summarizer.Client, note *notes.Note) string {
+	fmt.Println()
+	fmt.Println(ui.HeaderStyle.Render("AI Summary"))
+	fmt.Println(ui.MutedStyle.Render("Generating summary of completed work..."))
+
+	return runSummary(ctx, client, "Summary", func(ctx context.Context) (string, error) {
+		return client.SummarizeWorkItems(ctx, note.CompletedWork, summarizeContext(note.Date, note.CompletedWork))
+	})
+}
+
+// summarizeContext returns the date and item count to include in a summary
+// prompt, or nil unless AI_INCLUDE_CONTEXT is enabled
+func summarizeContext(date time.Time, items []notes.WorkItem) *summarizer.SummarizeContext {
+	if !cfg.AIIncludeContext {
+		return nil
+	}
+	return &summarizer.SummarizeContext{Date: date, Completed: len(items)}
+}
+
+// runSummary tests the connection, runs summarize and displays the result
+// under title, reporting failures as warnings and returning an empty summary.
+// Ctrl+C abandons the request and the command carries on without a summary.
+func runSummary(ctx context.Context, client *summarizer.Client, title string, summarize func(ctx context.Context) (string, error)) string {
+	ctx, stop := interruptible(ctx)
+	defer stop()
+
+	// Test connection first
+	if err := client.TestConnection(ctx); err != nil {
+		fmt.Println(ui.RenderWarning(fmt.Sprintf("Could not connect to OpenCode server: %v", err)))
+		fmt.Println(ui.MutedStyle.Render("Skipping AI summary generation."))
+		return ""
+	}
+
+	summary, err := summarize(ctx)
+	if err != nil {
+		fmt.Println(ui.RenderWarning(fmt.Sprintf("Could not generate summary: %v", err)))
+		return ""
+	}
+
+	fmt.Println()
+	prompter.DisplaySummaryBox(title, summary)
+	printModelFooter(client)
+
+	return summary
+}
+
+// printModelFooter names the model that generated the summary just shown
+func printModelFooter(client *summarizer.Client) {
+	if model := client.LastModel(); model != "" {
+		fmt.Println(ui.MutedStyle.Render("  generated by " + model))
+	}
+}
+
+// interruptible returns a context cancelled by the first Ctrl+C, for AI
+// requests the user may want to abandon without quitting. A second Ctrl+C, or
+// one outside such a request, stops the process as usual.
+func interruptible(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx, stop
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	catDate     string
+	catPathOnly bool
+)
+
+var catCmd = &cobra.Command{
+	Use:   "cat",
+	Short: "Print the raw markdown of a note",
+	Long: `Print the raw markdown contents of today's note file to stdout.
+
+Use --date to print a different day's note, or --path to print just the
+note's file path. Exits with an error if the note does not exist.`,
+	Args: cobra.NoArgs,
+	RunE: runCat,
+}
+
+func init() {
+	catCmd.Flags().StringVarP(&catDate, "date", "d", "", "Date of the note to print (YYYY-MM-DD, default today)")
+	catCmd.Flags().BoolVar(&catPathOnly, "path", false, "Print only the note's file path")
+	rootCmd.AddCommand(catCmd)
+}
+
+func runCat(cmd *cobra.Command, args []string) error {
+	date := time.Now().Truncate(24 * time.Hour)
+	if catDate != "" {
+		var err error
+		date, err = parseDate(catDate)
+		if err != nil {
+			return err
+		}
+	}
+
+	notePath := parser.NotePath(date)
+	if _, err := os.Stat(notePath); os.IsNotExist(err) {
+		return fmt.Errorf("no note found at %s", notePath)
+	}
+
+	if catPathOnly {
+		fmt.Println(notePath)
+		return nil
+	}
+
+	content, err := os.ReadFile(notePath)
+	if err != nil {
+		return fmt.Errorf("error reading note: %w", err)
+	}
+
+	_, err = os.Stdout.Write(content)
+	return err
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseDate parses a YYYY-MM-DD date flag value
+func parseDate(value string) (time.Time, error) {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", value)
+	}
+	return date, nil
+}
+
+// parseSince parses a --since value, either a YYYY-MM-DD date or a number of
+// days before today such as "3d"
+func parseSince(value string, today time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return time.Time{}, fmt.Errorf("invalid --since %q (expected e.g. 3d or YYYY-MM-DD)", value)
+		}
+		return today.AddDate(0, 0, -n), nil
+	}
+	return parseDate(value)
+}
+
+// parseWeekStart parses a week start day name such as "monday" or "sunday"
+func parseWeekStart(value string) (time.Weekday, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "monday", "mon":
+		return time.Monday, nil
+	case "sunday", "sun":
+		return time.Sunday, nil
+	case "saturday", "sat":
+		return time.Saturday, nil
+	default:
+		return time.Monday, fmt.Errorf("invalid week start %q (expected monday, sunday or saturday)", value)
+	}
+}
+
+// weekBounds returns the first day of the week containing date and the day
+// after its last day, so the range can be passed to FindNotesInRange
+func weekBounds(date time.Time, weekStart time.Weekday) (time.Time, time.Time) {
+	d := date.UTC()
+	day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) - int(weekStart) + 7) % 7
+	from := day.AddDate(0, 0, -offset)
+	return from, from.AddDate(0, 0, 7)
+}
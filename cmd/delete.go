@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
+	"github.com/sandepten/work-obsidian-noter/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deletePendingOnly   bool
+	deleteCompletedOnly bool
+)
+
+var deleteCmd = &cobra.Command{
+	Use:     "delete",
+	Aliases: []string{"rm"},
+	Short:   "Delete items from today's note",
+	Long: `Interactively delete pending and completed items from today's note.
+
+Each item is offered for deletion in turn, then a final summary of all
+selected items must be confirmed before the note is changed. Use
+--pending-only or --completed-only to go through just one section, and
+--dry-run to preview the deletion without changing the note.`,
+	Args: cobra.NoArgs,
+	RunE: runDelete,
+}
+
+func init() {
+	deleteCmd.Flags().BoolVar(&deletePendingOnly, "pending-only", false, "Only offer pending items for deletion")
+	deleteCmd.Flags().BoolVar(&deleteCompletedOnly, "completed-only", false, "Only offer completed items for deletion")
+	addDryRunFlag(deleteCmd)
+	addForceFlag(deleteCmd)
+	rootCmd.AddCommand(deleteCmd)
+}
+
+func runDelete(cmd *cobra.Command, args []string) error {
+	today := time.Now().Truncate(24 * time.Hour)
+
+	if deletePendingOnly && deleteCompletedOnly {
+		return fmt.Errorf("--pending-only cannot be combined with --completed-only")
+	}
+
+	// Get today's note
+	todayNote, err := parser.FindTodayNote(today)
+	if errors.Is(err, notes.ErrNoteNotFound) {
+		prompter.DisplayWarning("No note found for today. Use 'worklog start' to create one.")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error finding today's note: %w", err)
+	}
+	if err := checkNotFinalized(todayNote); err != nil {
+		return err
+	}
+
+	switch {
+	case deletePendingOnly && !todayNote.HasPendingWork():
+		prompter.DisplayMessage("Today's note has no pending items to delete.")
+		return nil
+	case deleteCompletedOnly && !todayNote.HasCompletedWork():
+		prompter.DisplayMessage("Today's note has no completed items to delete.")
+		return nil
+	case !todayNote.HasPendingWork() && !todayNote.HasCompletedWork():
+		prompter.DisplayMessage("Today's note has no items to delete.")
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(ui.TitleStyle.Render("🗑️  Delete Tasks"))
+	fmt.Println(ui.MutedStyle.Render("Choose which items to remove from today's note"))
+	fmt.Println(ui.RenderDivider(50))
+	fmt.Println()
+
+	return deleteSpecificTasks(todayNote)
+}
+
+// deleteSpecificTasks walks the pending and completed sections (or just one
+// with --pending-only or --completed-only) asking which items to delete, then
+// confirms the whole selection once before saving
+func deleteSpecificTasks(note *notes.Note) error {
+	var pendingIndices, completedIndices []int
+	var err error
+
+	if note.HasPendingWork() && !deleteCompletedOnly {
+		fmt.Println(ui.HeaderStyle.Render("Pending"))
+		pendingIndices, err = prompter.SelectTasksToDelete(note.PendingWork)
+		if err != nil {
+			return fmt.Errorf("error selecting items: %w", err)
+		}
+		fmt.Println()
+	}
+
+	if note.HasCompletedWork() && !deletePendingOnly {
+		fmt.Println(ui.HeaderStyle.Render("Done"))
+		completedIndices, err = prompter.SelectTasksToDelete(note.CompletedWork)
+		if err != nil {
+			return fmt.Errorf("error selecting items: %w", err)
+		}
+		fmt.Println()
+	}
+
+	total := len(pendingIndices) + len(completedIndices)
+	if total == 0 {
+		fmt.Println(ui.MutedStyle.Render("No items selected for deletion."))
+		fmt.Println()
+		return nil
+	}
+
+	// Show everything that is about to be removed and confirm once
+	fmt.Println(ui.RenderDivider(50))
+	fmt.Println(ui.RenderWarning(fmt.Sprintf("You are about to delete %d item(s):", total)))
+	for _, idx := range pendingIndices {
+		fmt.Println(ui.RenderPendingItem(idx+1, note.PendingWork[idx].Text))
+	}
+	for _, idx := range completedIndices {
+		fmt.Println(ui.RenderCompletedItem(idx+1, note.CompletedWork[idx].Text))
+	}
+	fmt.Println()
+
+	// A dry run changes nothing, so there is nothing to confirm
+	if !dryRun {
+		confirmed, err := prompter.ConfirmAction(fmt.Sprintf("Delete these %d item(s)", total))
+		if err != nil {
+			return fmt.Errorf("error confirming deletion: %w", err)
+		}
+		if !confirmed {
+			fmt.Println(ui.MutedStyle.Render("Deletion cancelled. No changes made."))
+			fmt.Println()
+			return nil
+		}
+	}
+
+	// Remove in descending order so earlier indices stay valid
+	sort.Sort(sort.Reverse(sort.IntSlice(pendingIndices)))
+	for _, idx := range pendingIndices {
+		note.RemovePendingItem(idx)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(completedIndices)))
+	for _, idx := range completedIndices {
+		note.RemoveCompletedItem(idx)
+	}
+
+	// Save the note
+	if err := saveNote(note); err != nil {
+		return fmt.Errorf("error saving note: %w", err)
+	}
+
+	fmt.Println()
+	if dryRun {
+		fmt.Println(ui.RenderInfo(fmt.Sprintf("Dry run: would delete %d item(s), leaving:", total)))
+	} else {
+		fmt.Println(ui.RenderSuccess(fmt.Sprintf("Deleted %d item(s)", total)))
+	}
+	fmt.Println()
+
+	prompter.DisplayWorkItems(note.PendingWork, note.CompletedWork)
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
+	"github.com/sandepten/work-obsidian-noter/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show what changed between the previous note and today's",
+	Long: `Compare today's note with the most recent previous note, matching items
+by text (ignoring case and spacing):
+
+  ✓  completed since the previous note: today, or recorded by start as done
+     after the previous note's day
+  +  new today
+  →  still pending, carried over from the previous note or an earlier day`,
+	Args: cobra.NoArgs,
+	RunE: runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+}
+
+// noteDiff is the comparison of today's items with the previous note's
+type noteDiff struct {
+	completed []notes.WorkItem
+	added     []notes.WorkItem
+	carried   []notes.WorkItem
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	today := time.Now().Truncate(24 * time.Hour)
+
+	todayNote, err := parser.FindTodayNote(today)
+	if errors.Is(err, notes.ErrNoteNotFound) {
+		prompter.DisplayWarning("No note found for today. Use 'worklog start' to create one.")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error finding today's note: %w", err)
+	}
+
+	previousNote, err := parser.FindMostRecentNote(today)
+	if err != nil {
+		return fmt.Errorf("error finding previous note: %w", err)
+	}
+	if previousNote == nil {
+		prompter.DisplayMessage("No previous note to compare with.")
+		return nil
+	}
+
+	diff := diffNotes(previousNote, todayNote)
+
+	fmt.Println()
+	fmt.Println(ui.TitleStyle.Render("📋 Changes Since Last Note"))
+	fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("%s (%s) → today", filepath.Base(previousNote.FilePath), ui.RelativeDate(previousNote.Date))))
+	fmt.Println(ui.RenderDivider(50))
+	fmt.Println()
+
+	if len(diff.completed)+len(diff.added)+len(diff.carried) == 0 {
+		fmt.Println(ui.RenderEmptyState("  No items in either note"))
+		fmt.Println()
+		return nil
+	}
+
+	printDiffSection("Completed", ui.IconCompleted, ui.CompletedItemStyle.Render, diff.completed)
+	printDiffSection("New", "+", ui.SuccessStyle.Render, diff.added)
+	printDiffSection("Carried Over", ui.IconArrow, ui.PendingItemStyle.Render, diff.carried)
+
+	return nil
+}
+
+// diffNotes sorts the items done since the previous note and today's pending
+// items into completed, new and carried over. start empties the previous
+// note's pending list: items finished since are recorded there with a done
+// date after the note's day, and the rest move to today keeping the earlier
+// date they were created on.
+func diffNotes(previous, today *notes.Note) noteDiff {
+	previousItems := make(map[string]bool)
+	for _, item := range append(append([]notes.WorkItem{}, previous.PendingWork...), previous.CompletedWork...) {
+		previousItems[notes.NormalizeItemText(item.Text)] = true
+	}
+
+	var diff noteDiff
+	for _, item := range previous.CompletedWork {
+		if item.CompletedAt.After(previous.Date) {
+			diff.completed = append(diff.completed, item)
+		}
+	}
+	diff.completed = append(diff.completed, today.CompletedWork...)
+
+	for _, item := range today.PendingWork {
+		carried := !item.CreatedAt.IsZero() && item.CreatedAt.Before(today.Date)
+		if carried || previousItems[notes.NormalizeItemText(item.Text)] {
+			diff.carried = append(diff.carried, item)
+		} else {
+			diff.added = append(diff.added, item)
+		}
+	}
+	return diff
+}
+
+// printDiffSection prints a heading and its items, each prefixed with a
+// marker in the section's color; empty sections are skipped
+func printDiffSection(title, marker string, render func(...string) string, items []notes.WorkItem) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Println(ui.HeaderStyle.Render(fmt.Sprintf("%s (%d)", title, len(items))))
+	for _, item := range items {
+		fmt.Printf("  %s %s\n", render(marker), render(item.Text))
+	}
+	fmt.Println()
+}
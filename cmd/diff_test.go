@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
+)
+
+func TestDiffNotes(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	texts := func(items []notes.WorkItem) []string {
+		var out []string
+		for _, item := range items {
+			out = append(out, item.Text)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name      string
+		previous  notes.Note
+		today     notes.Note
+		completed []string
+		added     []string
+		carried   []string
+	}{
+		{
+			name: "after start",
+			previous: notes.Note{
+				Date: day(16),
+				CompletedWork: []notes.WorkItem{
+					{Text: "Done on the day", CompletedAt: day(16)},
+					{Text: "Done on the day, undated"},
+					{Text: "Finished since", CompletedAt: day(17)},
+				},
+			},
+			today: notes.Note{
+				Date:          day(17),
+				PendingWork:   []notes.WorkItem{{Text: "Still open", CreatedAt: day(15)}, {Text: "Brand new", CreatedAt: day(17)}},
+				CompletedWork: []notes.WorkItem{{Text: "Quick fix"}},
+			},
+			completed: []string{"Finished since", "Quick fix"},
+			added:     []string{"Brand new"},
+			carried:   []string{"Still open"},
+		},
+		{
+			name: "before start",
+			previous: notes.Note{
+				Date:        day(16),
+				PendingWork: []notes.WorkItem{{Text: "Write docs"}},
+			},
+			today: notes.Note{
+				Date:        day(17),
+				PendingWork: []notes.WorkItem{{Text: "write  DOCS"}, {Text: "Undated new"}},
+			},
+			added:   []string{"Undated new"},
+			carried: []string{"write  DOCS"},
+		},
+		{
+			name:     "empty",
+			previous: notes.Note{Date: day(16)},
+			today:    notes.Note{Date: day(17)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := diffNotes(&tt.previous, &tt.today)
+			if got := texts(diff.completed); !slices.Equal(got, tt.completed) {
+				t.Errorf("completed = %q, want %q", got, tt.completed)
+			}
+			if got := texts(diff.added); !slices.Equal(got, tt.added) {
+				t.Errorf("added = %q, want %q", got, tt.added)
+			}
+			if got := texts(diff.carried); !slices.Equal(got, tt.carried) {
+				t.Errorf("carried = %q, want %q", got, tt.carried)
+			}
+		})
+	}
+}
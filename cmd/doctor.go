@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/sandepten/work-obsidian-noter/internal/config"
+	"github.com/sandepten/work-obsidian-noter/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the environment and report problems",
+	Long: `Report the tool version, resolved configuration, notes directory health
+and AI server reachability in one block, suitable for pasting into a bug
+report. Always exits 0; problems are flagged in the output.
+
+Unlike other commands, doctor runs with invalid config values, reporting
+them instead, and does not create a missing notes directory.`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// doctorReport collects check results and counts warnings and failures
+type doctorReport struct {
+	warnings int
+	failures int
+}
+
+// ok prints a passing check
+func (r *doctorReport) ok(label, detail string) {
+	fmt.Printf("  %s %-16s %s\n", ui.SuccessStyle.Render(ui.IconSuccess), label, detail)
+}
+
+// info prints a neutral line with no pass/fail status
+func (r *doctorReport) info(label, detail string) {
+	fmt.Printf("  %s %-16s %s\n", ui.MutedStyle.Render(ui.IconBullet), label, detail)
+}
+
+// warn prints a check that needs attention but does not stop the tool working
+func (r *doctorReport) warn(label, detail string) {
+	r.warnings++
+	fmt.Printf("  %s %-16s %s\n", ui.WarningStyle.Render(ui.IconWarning), label, ui.WarningStyle.Render(detail))
+}
+
+// fail prints a check that will stop commands from working
+func (r *doctorReport) fail(label, detail string) {
+	r.failures++
+	fmt.Printf("  %s %-16s %s\n", ui.ErrorStyle.Render(ui.IconError), label, ui.ErrorStyle.Render(detail))
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	report := &doctorReport{}
+
+	fmt.Println()
+	fmt.Println(ui.TitleStyle.Render("🩺 Worklog Doctor"))
+	fmt.Println(ui.RenderDivider(50))
+
+	fmt.Println(ui.HeaderStyle.Render("Tool"))
+	report.info("Version:", toolVersion())
+	report.info("Platform:", fmt.Sprintf("%s/%s, %s", runtime.GOOS, runtime.GOARCH, runtime.Version()))
+	fmt.Println()
+
+	fmt.Println(ui.HeaderStyle.Render("Config"))
+	configPath := config.Path()
+	if _, err := os.Stat(configPath); err == nil {
+		report.ok("Config file:", configPath)
+	} else if os.IsNotExist(err) {
+		report.warn("Config file:", configPath+" not found (using environment and defaults)")
+	} else {
+		report.fail("Config file:", fmt.Sprintf("%s: %v", configPath, err))
+	}
+	report.info("Workplace:", cfg.WorkplaceName)
+	if len(configErrors) == 0 {
+		report.ok("Values:", "valid")
+	}
+	for _, err := range configErrors {
+		report.fail("Values:", err.Error())
+	}
+	if cfg.VaultDetect {
+		report.info("Vault detection:", "on (notes folder from the vault's Daily notes settings)")
+	}
+	fmt.Println()
+
+	fmt.Println(ui.HeaderStyle.Render("Notes"))
+	checkNotesDirectory(report)
+	fmt.Println()
+
+	fmt.Println(ui.HeaderStyle.Render("AI"))
+	checkAI(cmd.Context(), report)
+	fmt.Println()
+
+	switch {
+	case report.failures > 0:
+		fmt.Println(ui.RenderError(fmt.Sprintf("%d problem(s) and %d warning(s) found", report.failures, report.warnings)))
+	case report.warnings > 0:
+		fmt.Println(ui.RenderWarning(fmt.Sprintf("%d warning(s) found", report.warnings)))
+	default:
+		fmt.Println(ui.RenderSuccess("All checks passed"))
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// checkNotesDirectory reports whether the notes directory exists, is
+// writable and how many notes it holds
+func checkNotesDirectory(report *doctorReport) {
+	dir := cfg.WorkNotesLocation
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		report.warn("Directory:", dir+" does not exist yet (other commands create it)")
+		return
+	}
+	if err != nil {
+		report.fail("Directory:", fmt.Sprintf("%s: %v", dir, err))
+		return
+	}
+	if !info.IsDir() {
+		report.fail("Directory:", dir+" is not a directory")
+		return
+	}
+	report.ok("Directory:", dir)
+
+	probe, err := os.CreateTemp(dir, ".worklog-doctor-*")
+	if err != nil {
+		report.fail("Writable:", fmt.Sprintf("cannot write to notes directory: %v", err))
+	} else {
+		probe.Close()
+		os.Remove(probe.Name())
+		report.ok("Writable:", "yes")
+	}
+
+	files, err := parser.ListNoteFiles()
+	if err != nil {
+		report.fail("Notes:", fmt.Sprintf("error listing notes: %v", err))
+		return
+	}
+	if len(files) == 0 {
+		report.warn("Notes:", fmt.Sprintf("no notes for %s yet", cfg.WorkplaceName))
+		return
+	}
+	report.ok("Notes:", fmt.Sprintf("%d (latest %s)", len(files), files[len(files)-1].Date.Format("Jan 2, 2006")))
+}
+
+// checkAI reports whether the OpenCode server is reachable and offers the
+// configured models
+func checkAI(ctx context.Context, report *doctorReport) {
+	if aiClient == nil {
+		if cfg.AIEnabled && !noAI {
+			report.info("Status:", "not checked (invalid AI config, see above)")
+			return
+		}
+		report.info("Status:", "disabled (AI_ENABLED=false or --no-ai)")
+		return
+	}
+
+	provider, models := aiClient.Models()
+	report.info("Server:", cfg.OpenCodeServer)
+	report.info("Models:", provider+"/"+strings.Join(models, ", "))
+
+	ctx, stop := interruptible(ctx)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Always contact the server; a cached success would hide an outage
+	if err := aiClient.Probe(ctx); err != nil {
+		report.fail("Reachable:", err.Error())
+		return
+	}
+	report.ok("Reachable:", "yes")
+
+	missing, err := aiClient.MissingModels(ctx)
+	switch {
+	case err != nil:
+		report.warn("Available:", err.Error())
+	case len(missing) == len(models):
+		report.fail("Available:", "none of the configured models are offered by the server")
+	case len(missing) > 0:
+		report.warn("Available:", "not offered by the server: "+strings.Join(missing, ", "))
+	default:
+		report.ok("Available:", "all configured models")
+	}
+}
+
+// toolVersion returns the module version and VCS revision recorded at build time
+func toolVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	version := info.Main.Version
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" && len(setting.Value) >= 12 {
+			version += " (" + setting.Value[:12] + ")"
+		}
+	}
+	return version
+}
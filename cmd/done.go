@@ -1,36 +1,88 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
 	"github.com/sandepten/work-obsidian-noter/internal/ui"
 	"github.com/spf13/cobra"
 )
 
+var (
+	doneSummarize bool
+	doneCount     int
+	doneMatch     string
+	doneUndoLast  bool
+)
+
 var doneCmd = &cobra.Command{
-	Use:   "done",
-	Short: "Mark pending items as completed",
-	Long:  `Interactively mark pending items as completed in today's note.`,
-	RunE:  runDone,
+	Use:     "done",
+	Aliases: []string{"d"},
+	Short:   "Mark pending items as completed",
+	Long: `Interactively mark pending items as completed in today's note.
+
+Use --summarize to generate an AI summary of today's completed work right
+after saving, and store it in the note's summary field.
+
+Use --count N to mark the first N pending items as completed without
+prompting. Items are taken in the order 'list' shows them: pinned items
+first, then the rest in note order.
+
+Use --match TEXT to mark every pending item containing TEXT (ignoring case)
+as completed. When more than one item matches, the matches are listed and
+must be confirmed first.
+
+Use --undo-last to move the last item in the completed section back to
+pending, for when something turned out not to be finished.`,
+	RunE: runDone,
 }
 
 func init() {
+	doneCmd.Flags().BoolVarP(&doneSummarize, "summarize", "s", false, "Generate and store an AI summary after marking items")
+	doneCmd.Flags().IntVarP(&doneCount, "count", "n", 0, "Mark the first N pending items as completed without prompting")
+	doneCmd.Flags().StringVarP(&doneMatch, "match", "m", "", "Mark pending items containing this text as completed")
+	doneCmd.Flags().BoolVar(&doneUndoLast, "undo-last", false, "Move the last completed item back to pending")
+	addForceFlag(doneCmd)
 	rootCmd.AddCommand(doneCmd)
 }
 
 func runDone(cmd *cobra.Command, args []string) error {
 	today := time.Now().Truncate(24 * time.Hour)
 
+	countSet := cmd.Flags().Changed("count")
+	if countSet && doneCount <= 0 {
+		return fmt.Errorf("--count must be positive")
+	}
+	matchSet := cmd.Flags().Changed("match")
+	if matchSet && countSet {
+		return fmt.Errorf("--match cannot be combined with --count")
+	}
+	if matchSet && strings.TrimSpace(doneMatch) == "" {
+		return fmt.Errorf("--match text cannot be empty")
+	}
+	if doneUndoLast && (countSet || matchSet || doneSummarize) {
+		return fmt.Errorf("--undo-last cannot be combined with --count, --match or --summarize")
+	}
+
 	// Get today's note
 	todayNote, err := parser.FindTodayNote(today)
+	if errors.Is(err, notes.ErrNoteNotFound) {
+		prompter.DisplayWarning("No note found for today. Use 'worklog start' to create one.")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("error finding today's note: %w", err)
 	}
+	if err := checkNotFinalized(todayNote); err != nil {
+		return err
+	}
 
-	if todayNote == nil {
-		prompter.DisplayWarning("No note found for today. Use 'worklog start' to create one.")
-		return nil
+	if doneUndoLast {
+		return undoLastCompleted(todayNote)
 	}
 
 	if !todayNote.HasPendingWork() {
@@ -40,15 +92,28 @@ func runDone(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	fmt.Println()
-	fmt.Println(ui.TitleStyle.Render("✓ Mark Tasks as Done"))
-	fmt.Println(ui.MutedStyle.Render("Select which tasks you've completed"))
-	fmt.Println(ui.RenderDivider(50))
-	fmt.Println()
+	var completedIndices []int
+	if countSet {
+		if doneCount > len(todayNote.PendingWork) {
+			return fmt.Errorf("--count %d exceeds the %d pending item(s)", doneCount, len(todayNote.PendingWork))
+		}
+		completedIndices = firstPendingIndices(todayNote.PendingWork, doneCount)
+	} else if matchSet {
+		completedIndices, err = confirmMatchingItems(todayNote.PendingWork, doneMatch)
+		if err != nil {
+			return err
+		}
+	} else {
+		fmt.Println()
+		fmt.Println(ui.TitleStyle.Render("✓ Mark Tasks as Done"))
+		fmt.Println(ui.MutedStyle.Render("Select which tasks you've completed"))
+		fmt.Println(ui.RenderDivider(50))
+		fmt.Println()
 
-	completedIndices, err := prompter.SelectPendingItems(todayNote.PendingWork)
-	if err != nil {
-		return fmt.Errorf("error selecting items: %w", err)
+		completedIndices, err = prompter.SelectPendingItems(todayNote.PendingWork)
+		if err != nil {
+			return fmt.Errorf("error selecting items: %w", err)
+		}
 	}
 
 	if len(completedIndices) == 0 {
@@ -65,7 +130,7 @@ func runDone(cmd *cobra.Command, args []string) error {
 	}
 
 	// Save the note
-	if err := writer.WriteNote(todayNote); err != nil {
+	if err := saveNote(todayNote); err != nil {
 		return fmt.Errorf("error saving note: %w", err)
 	}
 
@@ -77,5 +142,87 @@ func runDone(cmd *cobra.Command, args []string) error {
 	// Show updated state
 	prompter.DisplayWorkItems(todayNote.PendingWork, todayNote.CompletedWork)
 
+	if doneSummarize {
+		if aiClient == nil {
+			prompter.DisplayWarning("AI is disabled (AI_ENABLED=false or --no-ai). Skipping summary.")
+			return nil
+		}
+
+		if summary := generateSummary(cmd.Context(), aiClient, todayNote); summary != "" {
+			todayNote.Summary = summary
+			if err := saveNote(todayNote); err != nil {
+				return fmt.Errorf("error saving summary: %w", err)
+			}
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// undoLastCompleted moves the last completed item back to pending, keeping
+// markers such as its pin and created date
+func undoLastCompleted(note *notes.Note) error {
+	if !note.HasCompletedWork() {
+		prompter.DisplayMessage("Today's note has no completed items to reopen.")
+		return nil
+	}
+
+	last := len(note.CompletedWork) - 1
+	item := note.CompletedWork[last]
+	note.RemoveCompletedItem(last)
+	note.AppendPendingItem(item)
+
+	if err := saveNote(note); err != nil {
+		return fmt.Errorf("error saving note: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.RenderSuccess("Reopened the last completed item:"))
+	fmt.Println(ui.RenderPendingItem(prompter.DisplayNumber(note.PendingWork, len(note.PendingWork)-1), item.Text))
+	fmt.Println()
 	return nil
 }
+
+// firstPendingIndices returns the indices of the first n pending items in
+// display order (pinned first, then note order), sorted ascending
+func firstPendingIndices(items []notes.WorkItem, n int) []int {
+	indices := prompter.DisplayOrder(items)[:n]
+	sort.Ints(indices)
+	return indices
+}
+
+// confirmMatchingItems returns the indices of the pending items containing
+// text, ignoring case. Several matches are listed and must be confirmed; a
+// declined confirmation returns no indices.
+func confirmMatchingItems(items []notes.WorkItem, text string) ([]int, error) {
+	needle := strings.ToLower(strings.TrimSpace(text))
+
+	var indices []int
+	for i, item := range items {
+		if strings.Contains(strings.ToLower(item.Text), needle) {
+			indices = append(indices, i)
+		}
+	}
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("no pending items match %q", text)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.HeaderStyle.Render(fmt.Sprintf("Matching \"%s\"", text)))
+	for _, idx := range indices {
+		fmt.Println(ui.RenderPendingItem(prompter.DisplayNumber(items, idx), items[idx].Text))
+	}
+	fmt.Println()
+
+	if len(indices) > 1 {
+		confirmed, err := prompter.ConfirmAction(fmt.Sprintf("Mark these %d items as completed", len(indices)))
+		if err != nil {
+			return nil, fmt.Errorf("error confirming items: %w", err)
+		}
+		if !confirmed {
+			return nil, nil
+		}
+	}
+	return indices, nil
+}
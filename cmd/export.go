@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportDate  string
+	exportEmbed bool
+	exportLink  bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a day's note as markdown",
+	Long: `Export a single day's note as markdown to stdout.
+
+Use --embed to output only the task sections (no frontmatter, title or
+summaries) for pasting into an Obsidian daily note, or --link to output an
+Obsidian embed link such as ![[2025-01-19-Work]].`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportDate, "date", "d", "", "Date of the note to export (YYYY-MM-DD, default today)")
+	exportCmd.Flags().BoolVar(&exportEmbed, "embed", false, "Output only the task sections")
+	exportCmd.Flags().BoolVar(&exportLink, "link", false, "Output an Obsidian embed link to the note")
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	date := time.Now().Truncate(24 * time.Hour)
+	if exportDate != "" {
+		var err error
+		date, err = parseDate(exportDate)
+		if err != nil {
+			return err
+		}
+	}
+
+	if exportEmbed && exportLink {
+		return errors.New("--embed and --link cannot be used together")
+	}
+
+	if exportLink {
+		fmt.Println(notes.EmbedLink(date, cfg.WorkplaceName, cfg.WorkplaceSubdir))
+		return nil
+	}
+
+	note, err := parser.FindTodayNote(date)
+	if errors.Is(err, notes.ErrNoteNotFound) {
+		return fmt.Errorf("no note found for %s", date.Format("2006-01-02"))
+	}
+	if err != nil {
+		return fmt.Errorf("error finding note: %w", err)
+	}
+
+	if exportEmbed {
+		return writer.RenderTaskSections(os.Stdout, note)
+	}
+	return writer.Render(os.Stdout, note)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	filesFrom          string
+	filesTo            string
+	filesWorkplace     string
+	filesAllWorkplaces bool
+)
+
+var filesCmd = &cobra.Command{
+	Use:   "files",
+	Short: "Print note file paths for a date range",
+	Long: `Print the full path of each note file, one per line with no decoration,
+for use in shell pipelines:
+
+  worklog files --from 2025-01-01 | tar -czf notes.tgz -T -
+
+Every note is listed unless --from or --to narrow the range. Only notes for
+the configured workplace are included; use --workplace to pick another or
+--all-workplaces to include every dated note in the directory.`,
+	Args: cobra.NoArgs,
+	RunE: runFiles,
+}
+
+func init() {
+	filesCmd.Flags().StringVar(&filesFrom, "from", "", "Start date (YYYY-MM-DD)")
+	filesCmd.Flags().StringVar(&filesTo, "to", "", "End date, inclusive (YYYY-MM-DD)")
+	filesCmd.Flags().StringVar(&filesWorkplace, "workplace", "", "Workplace whose notes to list (default WORKPLACE_NAME)")
+	filesCmd.Flags().BoolVar(&filesAllWorkplaces, "all-workplaces", false, "List notes for every workplace")
+	rootCmd.AddCommand(filesCmd)
+}
+
+func runFiles(cmd *cobra.Command, args []string) error {
+	if filesAllWorkplaces && filesWorkplace != "" {
+		return fmt.Errorf("--workplace cannot be combined with --all-workplaces")
+	}
+
+	workplace := cfg.WorkplaceName
+	if filesWorkplace != "" {
+		workplace = filesWorkplace
+	}
+
+	// Zero dates leave that end of the range open
+	var from, to time.Time
+	var err error
+	if filesFrom != "" {
+		if from, err = parseDate(filesFrom); err != nil {
+			return err
+		}
+	}
+	if filesTo != "" {
+		if to, err = parseDate(filesTo); err != nil {
+			return err
+		}
+	}
+
+	// List every workplace's notes, which with PER_WORKPLACE_SUBDIR live in
+	// other folders than the configured workplace's
+	files, err := parser.ListAllNoteFiles()
+	if err != nil {
+		return fmt.Errorf("error listing notes: %w", err)
+	}
+
+	for _, file := range files {
+		if !from.IsZero() && file.Date.Before(from) {
+			continue
+		}
+		if !to.IsZero() && file.Date.After(to) {
+			continue
+		}
+		if !filesAllWorkplaces && !strings.EqualFold(file.Workplace(), workplace) {
+			continue
+		}
+		fmt.Println(absPath(file.Path))
+	}
+
+	return nil
+}
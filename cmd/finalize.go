@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
+	"github.com/sandepten/work-obsidian-noter/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	finalizeDate string
+
+	// forceFinalized lets commands that save a note change a finalized one
+	forceFinalized bool
+)
+
+var finalizeCmd = &cobra.Command{
+	Use:   "finalize",
+	Short: "Close a day's note against further changes",
+	Long: `Mark today's note, or another day's with --date, as finalized by setting
+finalized: true in its frontmatter.
+
+Once a note is finalized, every command that would change it, such as add,
+done, delete, pin, review, start and tidy, refuses unless --force is passed.
+Remove the field from the note to reopen the day.`,
+	Args: cobra.NoArgs,
+	RunE: runFinalize,
+}
+
+func init() {
+	finalizeCmd.Flags().StringVarP(&finalizeDate, "date", "d", "", "Finalize the note for this date (YYYY-MM-DD, default today)")
+	addDryRunFlag(finalizeCmd)
+	rootCmd.AddCommand(finalizeCmd)
+}
+
+// addForceFlag registers --force on a command that refuses to change finalized notes
+func addForceFlag(c *cobra.Command) {
+	c.Flags().BoolVar(&forceFinalized, "force", false, "Change the note even if it has been finalized")
+}
+
+// checkNotFinalized returns an error for a finalized note unless --force is set
+func checkNotFinalized(note *notes.Note) error {
+	if !note.Finalized || forceFinalized {
+		return nil
+	}
+	return fmt.Errorf("the note for %s is finalized; pass --force to change it", note.Date.Format("Mon, Jan 2, 2006"))
+}
+
+func runFinalize(cmd *cobra.Command, args []string) error {
+	date := time.Now().Truncate(24 * time.Hour)
+	if finalizeDate != "" {
+		var err error
+		if date, err = parseDate(finalizeDate); err != nil {
+			return err
+		}
+	}
+
+	note, err := parser.FindTodayNote(date)
+	if errors.Is(err, notes.ErrNoteNotFound) {
+		prompter.DisplayWarning(fmt.Sprintf("No note found for %s.", date.Format("Mon, Jan 2, 2006")))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error finding note: %w", err)
+	}
+
+	if note.Finalized {
+		prompter.DisplayMessage(fmt.Sprintf("%s is already finalized.", filepath.Base(note.FilePath)))
+		return nil
+	}
+
+	note.Finalized = true
+	if err := saveNote(note); err != nil {
+		return fmt.Errorf("error saving note: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.RenderSuccess(fmt.Sprintf("Finalized %s", filepath.Base(note.FilePath))))
+	fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("  %d done, %d pending", len(note.CompletedWork), len(note.PendingWork))))
+	fmt.Println()
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sandepten/work-obsidian-noter/internal/ui"
+)
+
+// heatmapWeeks is how many full weeks before the current one the heatmap covers
+const heatmapWeeks = 52
+
+// heatmapShades are the cells for increasing activity, from none to the busiest days
+var heatmapShades = []string{"·", "░", "▒", "▓", "█"}
+
+// runHeatmap renders completed-item counts per day over the last year as a
+// grid with one column per week and one row per weekday
+func runHeatmap(today time.Time) error {
+	weekStart, err := parseWeekStart(cfg.WeekStart)
+	if err != nil {
+		return fmt.Errorf("error in WEEK_START: %w", err)
+	}
+
+	thisWeek, _ := weekBounds(today, weekStart)
+	start := thisWeek.AddDate(0, 0, -7*heatmapWeeks)
+
+	rangeNotes, err := parser.FindNotesInRange(start, today.AddDate(0, 0, 1))
+	if err != nil {
+		return fmt.Errorf("error finding notes: %w", err)
+	}
+
+	counts := make(map[string]int)
+	total, busiest := 0, 0
+	for _, note := range rangeNotes {
+		key := note.Date.Format("2006-01-02")
+		counts[key] += len(note.CompletedWork)
+		total += len(note.CompletedWork)
+		busiest = max(busiest, counts[key])
+	}
+
+	fmt.Println()
+	fmt.Println(ui.TitleStyle.Render("📈 Activity"))
+	fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("%d item(s) completed since %s", total, start.Format("Jan 2, 2006"))))
+	fmt.Println(ui.RenderDivider(50))
+	fmt.Println()
+
+	// Month labels above the first week of each month, where there is room
+	columns := heatmapWeeks + 1
+	months := []byte(strings.Repeat(" ", columns+3))
+	for week := 0; week < columns; week++ {
+		day := start.AddDate(0, 0, 7*week)
+		if day.Day() <= 7 && week+3 <= columns {
+			copy(months[week:], day.Format("Jan"))
+		}
+	}
+	fmt.Printf("     %s\n", ui.MutedStyle.Render(strings.TrimRight(string(months), " ")))
+
+	for weekday := 0; weekday < 7; weekday++ {
+		var row strings.Builder
+		for week := 0; week < columns; week++ {
+			day := start.AddDate(0, 0, 7*week+weekday)
+			if day.After(today) {
+				break
+			}
+			row.WriteString(heatmapCell(counts[day.Format("2006-01-02")], busiest))
+		}
+		label := start.AddDate(0, 0, weekday).Format("Mon")
+		fmt.Printf("  %s %s\n", ui.MutedStyle.Render(label[:2]), row.String())
+	}
+
+	fmt.Println()
+	legend := make([]string, len(heatmapShades))
+	for i := range heatmapShades {
+		legend[i] = heatmapShade(i)
+	}
+	fmt.Printf("     %s %s %s\n", ui.MutedStyle.Render("Less"), strings.Join(legend, ""), ui.MutedStyle.Render("More"))
+	fmt.Println()
+
+	return nil
+}
+
+// heatmapCell renders a day's cell, shaded relative to the busiest day
+func heatmapCell(count, busiest int) string {
+	if count == 0 || busiest == 0 {
+		return heatmapShade(0)
+	}
+	// Spread counts of 1..busiest over the non-empty shades, rounding up so
+	// the busiest days get the darkest shade
+	shades := len(heatmapShades) - 1
+	return heatmapShade((count*shades + busiest - 1) / busiest)
+}
+
+// heatmapShade renders the cell for an activity level
+func heatmapShade(level int) string {
+	if level == 0 {
+		return ui.MutedStyle.Render(heatmapShades[0])
+	}
+	return ui.CompletedItemStyle.Render(heatmapShades[level])
+}
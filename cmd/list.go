@@ -1,46 +1,102 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
 	"github.com/sandepten/work-obsidian-noter/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 var (
-	pendingOnly bool
+	pendingOnly  bool
+	listCapacity time.Duration
+	listGroup    bool
+	listSince    string
+	listLimit    int
+	listAll      bool
+	listSortDone string
 )
 
 var listCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List today's work items",
-	Long:  `Display all pending and completed work items from today's note.`,
-	RunE:  runList,
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List today's work items",
+	Long: `Display all pending and completed work items from today's note.
+
+Use --since to show every note from a date (YYYY-MM-DD) or a number of days
+ago (e.g. 3d) through today, one day after another.
+
+Long lists are cut short after LIST_MAX_ITEMS items per section, or --limit;
+use --all to show everything. Use --sort-done recent to list the most recently
+completed items first, by the ✅ done date the Obsidian Tasks plugin adds.
+Items done on the same day, or without a date, follow in reverse note order,
+with undated items last.`,
+	RunE: runList,
 }
 
 func init() {
 	listCmd.Flags().BoolVarP(&pendingOnly, "pending", "p", false, "Show only pending tasks")
+	listCmd.Flags().DurationVar(&listCapacity, "capacity", 0, "Warn when pending estimates exceed this daily capacity (e.g. 6h)")
+	listCmd.Flags().BoolVarP(&listGroup, "group", "g", false, "Group items under their ### project headings")
+	listCmd.Flags().StringVar(&listSince, "since", "", "Show notes since a date (YYYY-MM-DD) or N days ago (e.g. 3d)")
+	listCmd.Flags().IntVar(&listLimit, "limit", 0, "Show at most N items per section (default from LIST_MAX_ITEMS)")
+	listCmd.Flags().BoolVar(&listAll, "all", false, "Show every item, ignoring LIST_MAX_ITEMS and --limit")
+	listCmd.Flags().StringVar(&listSortDone, "sort-done", "note", "Order of completed items: note (as written) or recent (latest done first)")
 	rootCmd.AddCommand(listCmd)
 }
 
 func runList(cmd *cobra.Command, args []string) error {
 	today := time.Now().Truncate(24 * time.Hour)
 
-	// Get today's note
-	todayNote, err := parser.FindTodayNote(today)
-	if err != nil {
-		return fmt.Errorf("error finding today's note: %w", err)
+	if listCapacity < 0 {
+		return fmt.Errorf("--capacity must be positive")
+	}
+
+	if listLimit < 0 {
+		return fmt.Errorf("--limit must be positive")
+	}
+	if listSortDone != "note" && listSortDone != "recent" {
+		return fmt.Errorf("invalid --sort-done %q (expected note or recent)", listSortDone)
+	}
+
+	prompter.SetGroupByProject(listGroup)
+	switch {
+	case listAll:
+		prompter.SetMaxItems(0)
+	case listLimit > 0:
+		prompter.SetMaxItems(listLimit)
+	default:
+		prompter.SetMaxItems(cfg.ListMaxItems)
+	}
+
+	if listSince != "" {
+		if listCapacity > 0 {
+			return fmt.Errorf("--capacity cannot be combined with --since")
+		}
+		since, err := parseSince(listSince, today)
+		if err != nil {
+			return err
+		}
+		return listSinceDate(since, today)
 	}
 
-	if todayNote == nil {
+	// Get today's note
+	todayNote, err := parser.FindTodayNote(today)
+	if errors.Is(err, notes.ErrNoteNotFound) {
 		prompter.DisplayWarning("No note found for today. Use 'worklog start' to create one.")
 		return nil
 	}
+	if err != nil {
+		return fmt.Errorf("error finding today's note: %w", err)
+	}
 
 	// Display date header with stats inline
 	dateStr := today.Format("Mon, Jan 2")
-	statsStr := fmt.Sprintf("%d pending · %d done", len(todayNote.PendingWork), len(todayNote.CompletedWork))
+	statsStr := fmt.Sprintf("%d pending · %d done · %d%%", len(todayNote.PendingWork), len(todayNote.CompletedWork), completionPercent(todayNote))
 	fmt.Printf("%s  %s\n", ui.TitleStyle.Render("📅 "+dateStr), ui.MutedStyle.Render(statsStr))
 
 	// Show yesterday's summary only if NOT using --pending flag
@@ -52,11 +108,83 @@ func runList(cmd *cobra.Command, args []string) error {
 	if pendingOnly {
 		prompter.DisplayPendingOnly(todayNote.PendingWork)
 	} else {
-		prompter.DisplayWorkItems(todayNote.PendingWork, todayNote.CompletedWork)
+		prompter.DisplayWorkItems(todayNote.PendingWork, sortCompleted(todayNote.CompletedWork))
+	}
+
+	// Compare pending estimates against the daily capacity
+	if listCapacity > 0 {
+		total, unestimated := todayNote.PendingEstimate()
+		line := fmt.Sprintf("⏱ Estimated %s of %s capacity", notes.FormatEstimate(total), notes.FormatEstimate(listCapacity))
+		if unestimated > 0 {
+			line += fmt.Sprintf(" (%d item(s) without estimate)", unestimated)
+		}
+		if total > listCapacity {
+			fmt.Println(ui.RenderWarning(fmt.Sprintf("Over capacity by %s — %s", notes.FormatEstimate(total-listCapacity), line)))
+		} else {
+			fmt.Println(ui.MutedStyle.Render(line))
+		}
 	}
 
 	// Show tip at the end
-	fmt.Println(ui.MutedStyle.Render("💡 Use 'worklog add \"task\"' to add items"))
+	if !ui.IsPlain() {
+		fmt.Println(ui.MutedStyle.Render("💡 Use 'worklog add \"task\"' to add items"))
+	}
+
+	return nil
+}
+
+// listSinceDate displays the items of every note from since through today, labeled by day
+func listSinceDate(since, today time.Time) error {
+	rangeNotes, err := parser.FindNotesInRange(since, today.AddDate(0, 0, 1))
+	if err != nil {
+		return fmt.Errorf("error finding notes: %w", err)
+	}
+
+	if len(rangeNotes) == 0 {
+		prompter.DisplayWarning(fmt.Sprintf("No notes found since %s.", since.Format("Jan 2, 2006")))
+		return nil
+	}
+
+	for _, note := range rangeNotes {
+		dateStr := note.Date.Format("Mon, Jan 2")
+		statsStr := fmt.Sprintf("%s · %d pending · %d done · %d%%", ui.RelativeDate(note.Date), len(note.PendingWork), len(note.CompletedWork), completionPercent(note))
+		fmt.Printf("%s  %s\n", ui.TitleStyle.Render("📅 "+dateStr), ui.MutedStyle.Render(statsStr))
+
+		if pendingOnly {
+			prompter.DisplayPendingOnly(note.PendingWork)
+		} else {
+			prompter.DisplayWorkItems(note.PendingWork, sortCompleted(note.CompletedWork))
+		}
+		fmt.Println()
+	}
 
 	return nil
 }
+
+// sortCompleted returns completed items in the --sort-done order; the note
+// itself is left unchanged. For recent, items are ordered by done date, newest
+// first, and otherwise by reverse note order, with undated items last.
+func sortCompleted(items []notes.WorkItem) []notes.WorkItem {
+	if listSortDone != "recent" {
+		return items
+	}
+	sorted := make([]notes.WorkItem, len(items))
+	for i, item := range items {
+		sorted[len(items)-1-i] = item
+	}
+	// An undated item has the zero time, so it sorts after every dated one
+	slices.SortStableFunc(sorted, func(a, b notes.WorkItem) int {
+		return b.CompletedAt.Compare(a.CompletedAt)
+	})
+	return sorted
+}
+
+// completionPercent returns the share of a note's items that are done, as a
+// whole percentage; a note with no items counts as 0%
+func completionPercent(note *notes.Note) int {
+	total := len(note.PendingWork) + len(note.CompletedWork)
+	if total == 0 {
+		return 0
+	}
+	return len(note.CompletedWork) * 100 / total
+}
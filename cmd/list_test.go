@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
+)
+
+func TestSortCompletedRecent(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	items := []notes.WorkItem{
+		{Text: "a", CompletedAt: day(15)},
+		{Text: "b"},
+		{Text: "c", CompletedAt: day(17)},
+		{Text: "d"},
+		{Text: "e", CompletedAt: day(15)},
+	}
+
+	listSortDone = "recent"
+	t.Cleanup(func() { listSortDone = "note" })
+
+	var got []string
+	for _, item := range sortCompleted(items) {
+		got = append(got, item.Text)
+	}
+	// Newest first, same-day items in reverse note order, undated ones last
+	if want := []string{"c", "e", "a", "d", "b"}; !slices.Equal(got, want) {
+		t.Errorf("sortCompleted = %q, want %q", got, want)
+	}
+	if items[0].Text != "a" || items[4].Text != "e" {
+		t.Error("sortCompleted reordered the note's items")
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sandepten/work-obsidian-noter/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// menuCommands are the commands offered by the interactive menu, in order
+var menuCommands = []*cobra.Command{
+	startCmd,
+	addCmd,
+	addManyCmd,
+	doneCmd,
+	listCmd,
+	reviewCmd,
+	summarizeCmd,
+	weekCmd,
+	statsCmd,
+}
+
+func init() {
+	rootCmd.Args = cobra.NoArgs
+	rootCmd.RunE = runMenu
+}
+
+// runMenu shows a menu of common commands when worklog is run without
+// arguments and runs the one chosen. Without a terminal it prints help.
+func runMenu(cmd *cobra.Command, args []string) error {
+	if !ui.IsTerminal(os.Stdin) {
+		return cmd.Help()
+	}
+
+	items := make([]string, 0, len(menuCommands)+1)
+	for _, c := range menuCommands {
+		items = append(items, fmt.Sprintf("%-10s %s", c.Name(), c.Short))
+	}
+	items = append(items, fmt.Sprintf("%-10s %s", "quit", "Exit without doing anything"))
+
+	fmt.Println()
+	fmt.Println(ui.TitleStyle.Render("📋 Worklog"))
+	fmt.Println(ui.MutedStyle.Render("Run 'worklog --help' to see every command"))
+	fmt.Println()
+
+	choice, err := prompter.SelectFromList("What would you like to do?", items)
+	if err != nil {
+		return fmt.Errorf("error selecting command: %w", err)
+	}
+	if choice == len(menuCommands) {
+		return nil
+	}
+
+	chosen := menuCommands[choice]
+	var chosenArgs []string
+	if chosen == addCmd {
+		text, err := prompter.PromptForNewItem()
+		if err != nil {
+			return fmt.Errorf("error reading task: %w", err)
+		}
+		if strings.TrimSpace(text) == "" {
+			return nil
+		}
+		chosenArgs = []string{text}
+	}
+
+	chosen.SetContext(cmd.Context())
+	return chosen.RunE(chosen, chosenArgs)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
+	"github.com/sandepten/work-obsidian-noter/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var migrateSubdirs bool
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Reorganize notes on disk",
+	Long: `Reorganize the notes directory.
+
+With --subdirs, every YYYY-MM-DD-Workplace.md note in the notes directory is
+moved to Workplace/YYYY-MM-DD.md, the layout used with
+PER_WORKPLACE_SUBDIR=true. Notes whose destination already exists are left
+in place. Use --dry-run to list the moves without making them.`,
+	Args: cobra.NoArgs,
+	RunE: runMigrate,
+}
+
+func init() {
+	migrateCmd.Flags().BoolVar(&migrateSubdirs, "subdirs", false, "Move notes into a folder per workplace")
+	addDryRunFlag(migrateCmd)
+	rootCmd.AddCommand(migrateCmd)
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	if !migrateSubdirs {
+		return fmt.Errorf("choose a migration to run, e.g. --subdirs")
+	}
+
+	// List the flat layout whatever PER_WORKPLACE_SUBDIR is set to
+	flat := notes.NewParser(cfg.WorkNotesLocation, cfg.WorkplaceName)
+	files, err := flat.ListNoteFiles()
+	if err != nil {
+		return fmt.Errorf("error listing notes: %w", err)
+	}
+
+	if len(files) == 0 {
+		prompter.DisplayMessage("No notes to move.")
+		return nil
+	}
+
+	moved, skipped := 0, 0
+	for _, file := range files {
+		workplace := file.Workplace()
+		dest := filepath.Join(cfg.WorkNotesLocation, workplace, notes.SubdirFilename(file.Date))
+		label := fmt.Sprintf("%s → %s", filepath.Base(file.Path), filepath.Join(workplace, filepath.Base(dest)))
+
+		if _, err := os.Stat(dest); err == nil {
+			fmt.Println(ui.RenderWarning(fmt.Sprintf("Skipped %s (destination exists)", label)))
+			skipped++
+			continue
+		}
+
+		if dryRun {
+			fmt.Println(ui.RenderInfo("Dry run: would move " + label))
+			moved++
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return fmt.Errorf("error creating workplace folder: %w", err)
+		}
+		if err := os.Rename(file.Path, dest); err != nil {
+			return fmt.Errorf("error moving note: %w", err)
+		}
+		fmt.Println(ui.RenderSuccess("Moved " + label))
+		moved++
+	}
+
+	fmt.Println()
+	if dryRun {
+		prompter.DisplayMessage(fmt.Sprintf("Would move %d note(s), skip %d.", moved, skipped))
+	} else {
+		prompter.DisplayMessage(fmt.Sprintf("Moved %d note(s), skipped %d.", moved, skipped))
+	}
+	if !cfg.WorkplaceSubdir {
+		fmt.Println(ui.MutedStyle.Render("Set PER_WORKPLACE_SUBDIR=true so worklog reads and writes the new layout."))
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
+	"github.com/sandepten/work-obsidian-noter/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var pinCmd = &cobra.Command{
+	Use:   "pin [item number]",
+	Short: "Pin or unpin a pending item",
+	Long: `Toggle the pinned state of a pending item in today's note.
+
+Pinned items are always shown first and marked with 📌. Pass the item's
+number as 'worklog list' shows it, or omit it to pick from a list.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPin,
+}
+
+func init() {
+	addForceFlag(pinCmd)
+	rootCmd.AddCommand(pinCmd)
+}
+
+func runPin(cmd *cobra.Command, args []string) error {
+	today := time.Now().Truncate(24 * time.Hour)
+
+	// Get today's note
+	todayNote, err := parser.FindTodayNote(today)
+	if errors.Is(err, notes.ErrNoteNotFound) {
+		prompter.DisplayWarning("No note found for today. Use 'worklog start' to create one.")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error finding today's note: %w", err)
+	}
+	if err := checkNotFinalized(todayNote); err != nil {
+		return err
+	}
+
+	if !todayNote.HasPendingWork() {
+		prompter.DisplayMessage("No pending items to pin.")
+		return nil
+	}
+
+	// Items are numbered and offered as list shows them, pinned ones first
+	order := prompter.DisplayOrder(todayNote.PendingWork)
+	var index int
+	if len(args) == 1 {
+		number, err := strconv.Atoi(args[0])
+		if err != nil || number < 1 || number > len(order) {
+			return fmt.Errorf("item number must be between 1 and %d", len(order))
+		}
+		index = order[number-1]
+	} else {
+		var labels []string
+		for _, i := range order {
+			item := todayNote.PendingWork[i]
+			label := item.Text
+			if item.Pinned {
+				label = ui.IconPinned + " " + label
+			}
+			labels = append(labels, label)
+		}
+
+		choice, err := prompter.SelectFromList("Select an item to pin or unpin", labels)
+		if err != nil {
+			return fmt.Errorf("error selecting item: %w", err)
+		}
+		index = order[choice]
+	}
+
+	pinned := todayNote.TogglePinned(index)
+
+	// Save the note
+	if err := saveNote(todayNote); err != nil {
+		return fmt.Errorf("error saving note: %w", err)
+	}
+
+	// Number the item as list now shows it, since pinning moves it
+	text := todayNote.PendingWork[index].Text
+	number := prompter.DisplayNumber(todayNote.PendingWork, index)
+	if pinned {
+		fmt.Println(ui.RenderSuccess(fmt.Sprintf("Pinned item %d: %s", number, text)))
+	} else {
+		fmt.Println(ui.RenderSuccess(fmt.Sprintf("Unpinned item %d: %s", number, text)))
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"html/template"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
+	"github.com/sandepten/work-obsidian-noter/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportFrom     string
+	reportTo       string
+	reportOut      string
+	reportObsidian bool
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Render a shareable HTML report for a date range",
+	Long: `Render the notes in a date range as a standalone HTML page, with tasks
+grouped by day alongside their AI summaries.
+
+By default the last 7 days (including today) are included and the page is
+written to report.html. Each item links back to its source note; with
+--obsidian the links open the note in Obsidian.`,
+	Args: cobra.NoArgs,
+	RunE: runReport,
+}
+
+func init() {
+	reportCmd.Flags().StringVar(&reportFrom, "from", "", "Start date (YYYY-MM-DD, default 6 days ago)")
+	reportCmd.Flags().StringVar(&reportTo, "to", "", "End date, inclusive (YYYY-MM-DD, default today)")
+	reportCmd.Flags().StringVarP(&reportOut, "out", "o", "report.html", "File to write the report to")
+	reportCmd.Flags().BoolVar(&reportObsidian, "obsidian", false, "Link items to their notes with obsidian:// URLs and [[wiki-links]]")
+	rootCmd.AddCommand(reportCmd)
+}
+
+// reportData is the data passed to the report template
+type reportData struct {
+	Workplace string
+	From      time.Time
+	To        time.Time
+	Days      []reportDay
+	Generated time.Time
+}
+
+// reportDay is a note in the report along with a link back to its source file
+type reportDay struct {
+	*notes.Note
+	Link  template.URL
+	Label string
+}
+
+// newReportDay builds the source link for a note: a file:// URL labelled with
+// the filename, or with --obsidian an obsidian:// URL labelled as a wiki-link
+func newReportDay(note *notes.Note) reportDay {
+	path, err := filepath.Abs(note.FilePath)
+	if err != nil {
+		path = note.FilePath
+	}
+	name := strings.TrimSuffix(filepath.Base(path), ".md")
+
+	if reportObsidian {
+		return reportDay{
+			Note:  note,
+			Link:  template.URL("obsidian://open?path=" + url.QueryEscape(path)),
+			Label: "[[" + name + "]]",
+		}
+	}
+	fileURL := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	return reportDay{Note: note, Link: template.URL(fileURL.String()), Label: filepath.Base(path)}
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Workplace}} work report: {{.From.Format "Jan 2"}} – {{.To.Format "Jan 2, 2006"}}</title>
+<style>
+  body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; max-width: 760px; margin: 2rem auto; padding: 0 1rem; color: #24292f; line-height: 1.5; }
+  h1 { color: #7c3aed; margin-bottom: 0; }
+  .range { color: #6b7280; margin-top: 0.25rem; }
+  .day { border: 1px solid #e5e7eb; border-radius: 8px; padding: 1rem 1.25rem; margin: 1.25rem 0; }
+  .day h2 { font-size: 1.1rem; margin: 0 0 0.5rem; }
+  .summary { background: #f5f3ff; border-left: 3px solid #7c3aed; padding: 0.5rem 0.75rem; margin: 0.5rem 0; }
+  h3 { font-size: 0.9rem; text-transform: uppercase; letter-spacing: 0.05em; color: #6b7280; margin: 0.75rem 0 0.25rem; }
+  ul { margin: 0; padding-left: 1.25rem; }
+  li.done { color: #15803d; }
+  li.pending { color: #b45309; }
+  .empty { color: #9ca3af; }
+  a.source { color: #9ca3af; font-size: 0.8rem; margin-left: 0.5rem; text-decoration: none; }
+  a.source:hover { text-decoration: underline; }
+  footer { color: #9ca3af; font-size: 0.8rem; margin-top: 2rem; }
+</style>
+</head>
+<body>
+<h1>{{.Workplace}} work report</h1>
+<p class="range">{{.From.Format "Mon, Jan 2, 2006"}} – {{.To.Format "Mon, Jan 2, 2006"}}</p>
+{{range .Days}}{{$day := .}}
+<section class="day">
+  <h2>{{.Date.Format "Monday, January 2"}} <a class="source" href="{{.Link}}">{{.Label}}</a></h2>
+  {{if .Summary}}<p class="summary">{{.Summary}}</p>{{end}}
+  <h3>Completed</h3>
+  {{if .CompletedWork}}<ul>{{range .CompletedWork}}
+    <li class="done">{{.Text}} <a class="source" href="{{$day.Link}}">{{$day.Label}}</a></li>{{end}}
+  </ul>{{else}}<p class="empty">Nothing completed</p>{{end}}
+  {{if .PendingWork}}<h3>Pending</h3>
+  <ul>{{range .PendingWork}}
+    <li class="pending">{{.Text}} <a class="source" href="{{$day.Link}}">{{$day.Label}}</a></li>{{end}}
+  </ul>{{end}}
+</section>
+{{else}}
+<p class="empty">No notes found in this range.</p>
+{{end}}
+<footer>Generated {{.Generated.Format "Jan 2, 2006 15:04"}}</footer>
+</body>
+</html>
+`))
+
+func runReport(cmd *cobra.Command, args []string) error {
+	today := time.Now().Truncate(24 * time.Hour)
+
+	to := today
+	if reportTo != "" {
+		var err error
+		if to, err = parseDate(reportTo); err != nil {
+			return err
+		}
+	}
+
+	from := to.AddDate(0, 0, -6)
+	if reportFrom != "" {
+		var err error
+		if from, err = parseDate(reportFrom); err != nil {
+			return err
+		}
+	}
+
+	if from.After(to) {
+		return fmt.Errorf("--from must not be after --to")
+	}
+
+	rangeNotes, err := parser.FindNotesInRange(from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return fmt.Errorf("error finding notes: %w", err)
+	}
+
+	file, err := os.Create(reportOut)
+	if err != nil {
+		return fmt.Errorf("error creating report file: %w", err)
+	}
+	defer file.Close()
+
+	data := reportData{
+		Workplace: cfg.WorkplaceName,
+		From:      from,
+		To:        to,
+		Generated: time.Now(),
+	}
+	for _, note := range rangeNotes {
+		data.Days = append(data.Days, newReportDay(note))
+	}
+	if err := reportTemplate.Execute(file, data); err != nil {
+		return fmt.Errorf("error rendering report: %w", err)
+	}
+
+	fmt.Println(ui.RenderSuccess(fmt.Sprintf("Wrote report for %d note(s) to %s", len(rangeNotes), reportOut)))
+	return nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"sort"
@@ -11,42 +12,131 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	reviewDepth  int
+	reviewSince  string
+	reviewCarry  bool
+	reviewTriage bool
+)
+
 var reviewCmd = &cobra.Command{
 	Use:   "review",
 	Short: "Review pending items from previous notes",
 	Long: `Manually review and process pending items from previous notes
-without creating a new note or generating summaries.`,
+without creating a new note or generating summaries.
+
+By default only the most recent previous note is reviewed. Use --depth to
+review the last N notes, or --since to review every note since a date.
+
+Items not marked completed stay where they are. Use --carry to move them all
+to today's note, or --triage to choose for each one whether to keep it,
+carry it to today or drop it.`,
 	RunE: runReview,
 }
 
 func init() {
+	reviewCmd.Flags().IntVarP(&reviewDepth, "depth", "n", 1, "Number of previous notes to review")
+	reviewCmd.Flags().StringVar(&reviewSince, "since", "", "Review all notes since this date (YYYY-MM-DD)")
+	reviewCmd.Flags().BoolVar(&reviewCarry, "carry", false, "Carry items not marked completed to today's note")
+	reviewCmd.Flags().BoolVar(&reviewTriage, "triage", false, "Ask whether to keep, carry or drop each item not marked completed")
+	addForceFlag(reviewCmd)
 	rootCmd.AddCommand(reviewCmd)
 }
 
+// pendingRef tracks which note a pending item came from during review
+type pendingRef struct {
+	note  *notes.Note
+	index int
+}
+
 func runReview(cmd *cobra.Command, args []string) error {
 	today := time.Now().Truncate(24 * time.Hour)
 
-	// Find the most recent previous note
-	previousNote, err := parser.FindMostRecentNote(today)
-	if err != nil {
-		return fmt.Errorf("error finding previous note: %w", err)
+	if reviewDepth < 1 {
+		return fmt.Errorf("--depth must be at least 1")
+	}
+
+	// Find the previous notes to review, oldest first
+	var previousNotes []*notes.Note
+	if reviewSince != "" {
+		since, err := parseDate(reviewSince)
+		if err != nil {
+			return err
+		}
+		previousNotes, err = parser.FindNotesInRange(since, today)
+		if err != nil {
+			return fmt.Errorf("error finding previous notes: %w", err)
+		}
+	} else {
+		recent, err := parser.FindRecentNotes(today, reviewDepth)
+		if err != nil {
+			return fmt.Errorf("error finding previous notes: %w", err)
+		}
+		for i := len(recent) - 1; i >= 0; i-- {
+			previousNotes = append(previousNotes, recent[i])
+		}
 	}
 
-	if previousNote == nil {
+	if len(previousNotes) == 0 {
 		prompter.DisplayMessage("No previous notes found.")
 		return nil
 	}
 
+	// Check up front that every note the review may change is open
+	for _, note := range previousNotes {
+		if note.HasPendingWork() {
+			if err := checkNotFinalized(note); err != nil {
+				return err
+			}
+		}
+	}
+	var todayNote *notes.Note
+	if reviewTriage || reviewCarry {
+		var err error
+		todayNote, err = parser.FindTodayNote(today)
+		if err != nil && !errors.Is(err, notes.ErrNoteNotFound) {
+			return fmt.Errorf("error finding today's note: %w", err)
+		}
+		if todayNote != nil {
+			if err := checkNotFinalized(todayNote); err != nil {
+				return err
+			}
+		}
+	}
+
+	multiple := len(previousNotes) > 1
+
 	fmt.Println()
-	fmt.Println(ui.TitleStyle.Render("📝 Review Previous Note"))
-	fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("📄 %s (%s)", filepath.Base(previousNote.FilePath), previousNote.Date.Format("January 2, 2006"))))
+	if multiple {
+		fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("📝 Review %d Previous Notes", len(previousNotes))))
+	} else {
+		fmt.Println(ui.TitleStyle.Render("📝 Review Previous Note"))
+	}
+	for _, note := range previousNotes {
+		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("📄 %s (%s · %s)", filepath.Base(note.FilePath), note.Date.Format("January 2, 2006"), ui.RelativeDate(note.Date))))
+	}
 	fmt.Println(ui.RenderDivider(50))
 	fmt.Println()
 
-	if !previousNote.HasPendingWork() {
+	// Collect pending items across all notes, tracking where each came from
+	var pendingItems []notes.WorkItem
+	var refs []pendingRef
+	for _, note := range previousNotes {
+		for i, item := range note.PendingWork {
+			if multiple {
+				item.Text = fmt.Sprintf("%s (%s)", item.Text, note.Date.Format("Jan 2"))
+			}
+			pendingItems = append(pendingItems, item)
+			refs = append(refs, pendingRef{note: note, index: i})
+		}
+	}
+
+	if len(pendingItems) == 0 {
 		fmt.Println(ui.RenderSuccess("No pending items to review — all caught up! 🎉"))
 		fmt.Println()
-		prompter.DisplayWorkItems(previousNote.PendingWork, previousNote.CompletedWork)
+		if !multiple {
+			prompter.DisplayWorkItems(previousNotes[0].PendingWork, previousNotes[0].CompletedWork)
+		}
 		return nil
 	}
 
@@ -54,44 +144,160 @@ func runReview(cmd *cobra.Command, args []string) error {
 	fmt.Println(ui.MutedStyle.Render("Mark items you've completed"))
 	fmt.Println()
 
-	completedIndices, err := prompter.SelectPendingItems(previousNote.PendingWork)
+	completedIndices, err := prompter.SelectPendingItems(pendingItems)
 	if err != nil {
 		return fmt.Errorf("error reviewing items: %w", err)
 	}
 
-	if len(completedIndices) == 0 {
+	// Decide what happens to the items left pending
+	var carriedIndices, droppedIndices []int
+	if reviewTriage || reviewCarry {
+		carriedIndices, droppedIndices, err = triageRemaining(pendingItems, completedIndices)
+		if err != nil {
+			return fmt.Errorf("error triaging items: %w", err)
+		}
+	}
+
+	if len(completedIndices)+len(carriedIndices)+len(droppedIndices) == 0 {
 		fmt.Println()
 		fmt.Println(ui.MutedStyle.Render("No items marked as completed."))
 		fmt.Println()
 		return nil
 	}
 
-	// Sort indices in descending order
-	sort.Sort(sort.Reverse(sort.IntSlice(completedIndices)))
+	// Carried items go to today's note in review order
+	if len(carriedIndices) > 0 {
+		if todayNote == nil {
+			if todayNote, err = createNote(today); err != nil {
+				return fmt.Errorf("error creating today's note: %w", err)
+			}
+		}
+		for _, idx := range carriedIndices {
+			ref := refs[idx]
+			todayNote.AppendPendingItem(ref.note.PendingWork[ref.index])
+		}
+		if err := saveNote(todayNote); err != nil {
+			return fmt.Errorf("error saving today's note: %w", err)
+		}
+	}
 
-	// Mark items as completed
+	// Group completed and removed items by the note they came from
+	completedByNote := make(map[*notes.Note][]int)
 	for _, idx := range completedIndices {
-		item := previousNote.PendingWork[idx]
-		previousNote.CompletedWork = append(previousNote.CompletedWork, notes.WorkItem{
-			Text:      item.Text,
-			Completed: true,
-		})
-		// Remove from pending
-		previousNote.PendingWork = append(previousNote.PendingWork[:idx], previousNote.PendingWork[idx+1:]...)
+		ref := refs[idx]
+		completedByNote[ref.note] = append(completedByNote[ref.note], ref.index)
+	}
+	removedByNote := make(map[*notes.Note]map[int]bool)
+	for _, idx := range append(carriedIndices, droppedIndices...) {
+		ref := refs[idx]
+		if removedByNote[ref.note] == nil {
+			removedByNote[ref.note] = make(map[int]bool)
+		}
+		removedByNote[ref.note][ref.index] = true
 	}
 
-	// Save the note
-	if err := writer.WriteNote(previousNote); err != nil {
-		return fmt.Errorf("error saving note: %w", err)
+	updated := make(map[*notes.Note]bool)
+	for _, note := range previousNotes {
+		completed := completedByNote[note]
+		removed := removedByNote[note]
+		if len(completed) == 0 && len(removed) == 0 {
+			continue
+		}
+		if removed == nil {
+			removed = make(map[int]bool)
+		}
+
+		// Mark items as completed in their original order
+		sort.Ints(completed)
+		for _, idx := range completed {
+			item := note.PendingWork[idx]
+			item.Completed = true
+			note.CompletedWork = append(note.CompletedWork, item)
+			removed[idx] = true
+		}
+
+		// Remove completed, carried and dropped items from pending, last first
+		var indices []int
+		for idx := range removed {
+			indices = append(indices, idx)
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(indices)))
+		for _, idx := range indices {
+			note.RemovePendingItem(idx)
+		}
+
+		// Save the note
+		if err := saveNote(note); err != nil {
+			return fmt.Errorf("error saving note: %w", err)
+		}
+		updated[note] = true
 	}
 
 	fmt.Println()
 	fmt.Println(ui.RenderDivider(50))
-	fmt.Println(ui.RenderSuccess(fmt.Sprintf("Marked %d item(s) as completed!", len(completedIndices))))
+	if len(completedIndices) > 0 {
+		fmt.Println(ui.RenderSuccess(fmt.Sprintf("Marked %d item(s) as completed!", len(completedIndices))))
+	}
+	if len(carriedIndices) > 0 {
+		fmt.Println(ui.RenderSuccess(fmt.Sprintf("Carried %d item(s) to today's note", len(carriedIndices))))
+	}
+	if len(droppedIndices) > 0 {
+		fmt.Println(ui.RenderSuccess(fmt.Sprintf("Dropped %d item(s)", len(droppedIndices))))
+	}
 	fmt.Println()
 
 	// Show updated state
-	prompter.DisplayWorkItems(previousNote.PendingWork, previousNote.CompletedWork)
+	if multiple {
+		for _, note := range previousNotes {
+			if updated[note] {
+				fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("ℹ Updated: %s", filepath.Base(note.FilePath))))
+			}
+		}
+		fmt.Println()
+	} else {
+		prompter.DisplayWorkItems(previousNotes[0].PendingWork, previousNotes[0].CompletedWork)
+	}
 
 	return nil
 }
+
+// triageActions are the choices offered for each item left pending with --triage
+var triageActions = []string{"Keep", "Carry to today", "Drop"}
+
+// triageRemaining decides the fate of every item not marked completed. With
+// --carry they are all carried to today; with --triage each one is asked about.
+func triageRemaining(items []notes.WorkItem, completedIndices []int) (carried, dropped []int, err error) {
+	completedSet := make(map[int]bool)
+	for _, idx := range completedIndices {
+		completedSet[idx] = true
+	}
+
+	if reviewTriage {
+		fmt.Println()
+		fmt.Println(ui.RenderInfo("Triage remaining items:"))
+		fmt.Println()
+	}
+
+	for i, item := range items {
+		if completedSet[i] {
+			continue
+		}
+		if !reviewTriage {
+			carried = append(carried, i)
+			continue
+		}
+
+		choice, err := prompter.SelectFromList(fmt.Sprintf("\"%s\"", item.Text), triageActions)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch triageActions[choice] {
+		case "Carry to today":
+			carried = append(carried, i)
+		case "Drop":
+			dropped = append(dropped, i)
+		}
+	}
+
+	return carried, dropped, nil
+}
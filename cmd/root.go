@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/sandepten/work-obsidian-noter/internal/config"
 	"github.com/sandepten/work-obsidian-noter/internal/notes"
@@ -17,6 +18,15 @@ var (
 	writer   *notes.Writer
 	prompter *ui.Prompter
 	aiClient *summarizer.Client
+
+	noAI        bool
+	asciiOutput bool
+	plainOutput bool
+	showPath    bool
+
+	// configErrors holds invalid config values found by initConfig. Every
+	// command but doctor, which reports them, exits when there are any.
+	configErrors []error
 )
 
 // rootCmd represents the base command
@@ -27,10 +37,30 @@ var rootCmd = &cobra.Command{
 	
 Track your pending and completed work items, review yesterday's tasks,
 and get AI-powered summaries of your accomplishments.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		commandName = cmd.Name()
+
+		// doctor reports config problems and a missing notes directory itself
+		if cmd == doctorCmd {
+			return
+		}
+		for _, err := range configErrors {
+			fmt.Fprintf(os.Stderr, "Error in config: %v\n", err)
+		}
+		if len(configErrors) > 0 {
+			os.Exit(1)
+		}
+		if err := cfg.EnsureNotesDirectory(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating notes directory: %v\n", err)
+			os.Exit(1)
+		}
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
+	registerAliases()
+
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
@@ -39,26 +69,114 @@ func Execute() {
 
 func init() {
 	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentFlags().BoolVar(&noAI, "no-ai", false, "Disable all AI features for this run")
+	rootCmd.PersistentFlags().BoolVar(&plainOutput, "plain", false, "Script-friendly output: no colors, emoji, borders or tips")
+	rootCmd.PersistentFlags().BoolVar(&showPath, "show-path", false, "Print the full path of each note written")
+	rootCmd.PersistentFlags().BoolVar(&asciiOutput, "ascii", false, "Use ASCII-only output (auto-enabled for non-UTF-8 locales)")
+}
+
+// registerAliases adds the user-defined command aliases from ALIASES, a
+// comma-separated list of alias=command pairs. Cobra resolves the command
+// before initConfig runs, so the config is read here first. Invalid entries
+// are skipped and added to configErrors.
+func registerAliases() {
+	// Invalid values elsewhere in the config are reported by initConfig
+	loaded, _ := config.Load()
+	if strings.TrimSpace(loaded.Aliases) == "" {
+		return
+	}
+
+	for _, pair := range strings.Split(loaded.Aliases, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		alias, name, ok := strings.Cut(pair, "=")
+		alias, name = strings.TrimSpace(alias), strings.TrimSpace(name)
+		if !ok || alias == "" || name == "" {
+			configErrors = append(configErrors, fmt.Errorf("invalid ALIASES entry %q (expected alias=command)", pair))
+			continue
+		}
+
+		target, _, err := rootCmd.Find([]string{name})
+		if err != nil || target == rootCmd {
+			configErrors = append(configErrors, fmt.Errorf("ALIASES entry %q names unknown command %q", pair, name))
+			continue
+		}
+		if existing, _, err := rootCmd.Find([]string{alias}); err == nil && existing != rootCmd {
+			configErrors = append(configErrors, fmt.Errorf("alias %q is already the command or alias %q", alias, existing.Name()))
+			continue
+		}
+		target.Aliases = append(target.Aliases, alias)
+	}
 }
 
-// initConfig reads configuration and initializes dependencies
+// initConfig reads configuration and initializes dependencies. Invalid values
+// are collected in configErrors and replaced by their defaults.
 func initConfig() {
+	// Switch output mode before anything is printed
+	if plainOutput {
+		ui.SetPlain()
+	} else if asciiOutput || ui.PreferASCII() {
+		ui.SetASCII()
+	}
+
 	var err error
 	cfg, err = config.Load()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
-		os.Exit(1)
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		configErrors = append(configErrors, joined.Unwrap()...)
+	} else if err != nil {
+		configErrors = append(configErrors, err)
 	}
 
-	// Ensure notes directory exists
-	if err := cfg.EnsureNotesDirectory(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating notes directory: %v\n", err)
-		os.Exit(1)
+	// Custom item icons; plain output keeps its fixed [ ] and [x] for scripts
+	if !plainOutput {
+		ui.SetItemIcons(cfg.IconPending, cfg.IconDone)
 	}
 
 	// Initialize dependencies
 	parser = notes.NewParser(cfg.WorkNotesLocation, cfg.WorkplaceName)
+	parser.SetWarningHandler(func(message string) {
+		fmt.Fprintln(os.Stderr, ui.RenderWarning(message))
+	})
+	parser.SetWorkplaceSubdir(cfg.WorkplaceSubdir)
 	writer = notes.NewWriter(cfg.WorkNotesLocation, cfg.WorkplaceName)
+	writer.SetWorkplaceSubdir(cfg.WorkplaceSubdir)
+	writer.SetCompletedInline(cfg.CompletedInline)
+	switch strings.ToLower(strings.TrimSpace(cfg.LineEndings)) {
+	case "lf":
+	case "crlf":
+		writer.SetCRLF(true)
+	default:
+		configErrors = append(configErrors, fmt.Errorf("invalid LINE_ENDINGS %q (expected lf or crlf)", cfg.LineEndings))
+	}
+	switch strings.ToLower(strings.TrimSpace(cfg.TagsStyle)) {
+	case "block":
+	case "inline":
+		writer.SetInlineTags(true)
+	default:
+		configErrors = append(configErrors, fmt.Errorf("invalid TAGS_STYLE %q (expected block or inline)", cfg.TagsStyle))
+	}
+	switch strings.ToLower(strings.TrimSpace(cfg.CheckboxCase)) {
+	case "lower":
+	case "upper":
+		writer.SetCheckboxCase(notes.CheckboxUpper)
+	case "preserve":
+		writer.SetCheckboxCase(notes.CheckboxPreserve)
+	default:
+		configErrors = append(configErrors, fmt.Errorf("invalid CHECKBOX_CASE %q (expected lower, upper or preserve)", cfg.CheckboxCase))
+	}
+	switch strings.ToLower(strings.TrimSpace(cfg.AddPosition)) {
+	case "bottom", "top":
+	default:
+		configErrors = append(configErrors, fmt.Errorf("invalid ADD_POSITION %q (expected top or bottom)", cfg.AddPosition))
+	}
 	prompter = ui.NewPrompter()
-	aiClient = summarizer.NewClient(cfg.OpenCodeServer, cfg.AIProvider, cfg.AIModel)
+
+	// Leave aiClient nil when AI is disabled so commands skip AI logic entirely
+	if cfg.AIEnabled && !noAI {
+		aiClient, err = newAIClient(cfg.AIProvider, cfg.AIModel)
+		if err != nil {
+			configErrors = append(configErrors, err)
+		}
+	}
 }
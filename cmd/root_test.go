@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegisterAliasesCollectsErrors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("ALIASES", "x,ls=list,zz=nope")
+	t.Cleanup(func() { configErrors = nil })
+
+	// Reaching the checks below means no entry exited the process
+	registerAliases()
+
+	var got []string
+	for _, err := range configErrors {
+		got = append(got, err.Error())
+	}
+	want := []string{`invalid ALIASES entry "x"`, `alias "ls" is already`, `names unknown command "nope"`}
+	if len(got) != len(want) {
+		t.Fatalf("configErrors = %q, want %d errors", got, len(want))
+	}
+	for i := range want {
+		if !strings.Contains(got[i], want[i]) {
+			t.Errorf("configErrors[%d] = %q, want it to contain %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
+	"github.com/sandepten/work-obsidian-noter/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// shownPaths records the paths already printed by --show-path, so a note
+	// written several times in one run is only listed once
+	shownPaths = make(map[string]bool)
+
+	// commandName is the name of the running command, used in auto-commit messages
+	commandName string
+
+	// dryRun makes saveNote report the write instead of performing it
+	dryRun bool
+
+	// createdNotes holds the paths of notes created in this run and not yet
+	// announced, so a new note is listed and committed even if it is saved
+	// without further changes
+	createdNotes = make(map[string]bool)
+
+	// gitWarned is set once a git auto-commit warning has been shown, so a
+	// command that writes several notes does not repeat it
+	gitWarned bool
+)
+
+// addDryRunFlag registers --dry-run on a command that changes notes
+func addDryRunFlag(c *cobra.Command) {
+	c.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without writing any files")
+}
+
+// createNote creates the note for date from the empty template, remembering
+// it so the next saveNote or announceWrite lists and commits it
+func createNote(date time.Time) (*notes.Note, error) {
+	note, err := writer.CreateTodayNote(date)
+	if err != nil {
+		return nil, err
+	}
+	createdNotes[absPath(note.FilePath)] = true
+	return note, nil
+}
+
+// saveNote writes a note to disk, prints the path written with --show-path
+// and commits it when GIT_AUTOCOMMIT is enabled. A note whose file is already
+// up to date is not written, listed or committed, unless createNote made it
+// in this run. With --dry-run it only reports the file that would be written.
+func saveNote(note *notes.Note) error {
+	if dryRun {
+		fmt.Println(ui.RenderInfo("Dry run: would write " + absPath(note.FilePath)))
+		return nil
+	}
+
+	path, written, err := writer.WriteNote(note)
+	if err != nil {
+		return err
+	}
+	if written || createdNotes[absPath(path)] {
+		announceWrite(note, path)
+	}
+	return nil
+}
+
+// announceWrite prints a written note's path with --show-path and commits it
+// when GIT_AUTOCOMMIT is enabled. Commands that save a note several times as
+// work in progress call it once at the end.
+func announceWrite(note *notes.Note, path string) {
+	delete(createdNotes, absPath(path))
+	showWrittenPath(path)
+	if cfg.GitAutoCommit {
+		commitNote(note, path)
+	}
+}
+
+// showWrittenPath prints the full path of a written note on its own line
+// when --show-path is set, so it can be piped to other tools
+func showWrittenPath(path string) {
+	path = absPath(path)
+	if !showPath || shownPaths[path] {
+		return
+	}
+	shownPaths[path] = true
+	fmt.Println(path)
+}
+
+// absPath returns path made absolute, or unchanged if that fails
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// commitNote commits a written note to the git repository holding it. Any
+// failure is reported as a warning; the note itself has already been saved.
+func commitNote(note *notes.Note, path string) {
+	dir := filepath.Dir(path)
+	message := fmt.Sprintf("worklog: %s %s %s", commandName, cfg.WorkplaceName, note.Date.Format("2006-01-02"))
+
+	if _, err := git(dir, "add", "--", path); err != nil {
+		warnGit(err)
+		return
+	}
+
+	// Nothing staged for this file means the write did not change it
+	if _, err := git(dir, "diff", "--cached", "--quiet", "--", path); err == nil {
+		return
+	}
+
+	if _, err := git(dir, "commit", "--quiet", "-m", message, "--", path); err != nil {
+		warnGit(err)
+	}
+}
+
+// git runs a git command in dir, returning its output or an error carrying
+// git's own message
+func git(dir string, args ...string) (string, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", errors.New("git is not installed")
+	}
+
+	var stderr bytes.Buffer
+	command := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	command.Stderr = &stderr
+	out, err := command.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return string(out), nil
+}
+
+// warnGit prints a git auto-commit failure to stderr once per run, keeping
+// it out of output piped to other tools
+func warnGit(err error) {
+	if gitWarned {
+		return
+	}
+	gitWarned = true
+	fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("Git auto-commit skipped: %v", err)))
+}
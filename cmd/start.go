@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
-	"sort"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/sandepten/work-obsidian-noter/internal/notes"
@@ -11,6 +14,8 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var startNoSummary bool
+
 var startCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start daily workflow",
@@ -23,12 +28,20 @@ var startCmd = &cobra.Command{
 }
 
 func init() {
+	startCmd.Flags().BoolVar(&startNoSummary, "no-summary", false, "Skip AI summary generation for this run")
+	addAIOverrideFlags(startCmd)
+	addForceFlag(startCmd)
 	rootCmd.AddCommand(startCmd)
 }
 
 func runStart(cmd *cobra.Command, args []string) error {
 	today := time.Now().Truncate(24 * time.Hour)
 
+	client, err := summarizerFor(cmd)
+	if err != nil {
+		return err
+	}
+
 	fmt.Println()
 	fmt.Println(ui.TitleStyle.Render("🚀 Daily Workflow"))
 	fmt.Println(ui.MutedStyle.Render(today.Format("Monday, January 2, 2006")))
@@ -37,7 +50,7 @@ func runStart(cmd *cobra.Command, args []string) error {
 
 	// Check if today's note already exists
 	todayNote, err := parser.FindTodayNote(today)
-	if err != nil {
+	if err != nil && !errors.Is(err, notes.ErrNoteNotFound) {
 		return fmt.Errorf("error checking for today's note: %w", err)
 	}
 
@@ -47,9 +60,29 @@ func runStart(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error finding previous note: %w", err)
 	}
 
+	// Refuse before any prompts to change a finalized note: today's, or the
+	// previous one when it has items to review or completed work to summarize
+	if todayNote != nil {
+		if err := checkNotFinalized(todayNote); err != nil {
+			return err
+		}
+	}
+	summarize := client != nil && !startNoSummary
+	if previousNote != nil && (previousNote.HasPendingWork() || (summarize && previousNote.HasCompletedWork())) {
+		if err := checkNotFinalized(previousNote); err != nil {
+			return err
+		}
+	}
+
+	recap := startRecap{}
+
 	// Create today's note if it doesn't exist
 	if todayNote == nil {
-		todayNote = writer.CreateTodayNote(today)
+		recap.created = true
+		todayNote, err = createNote(today)
+		if err != nil {
+			return fmt.Errorf("error creating today's note: %w", err)
+		}
 		fmt.Println(ui.RenderSuccess(fmt.Sprintf("Created new note: %s", filepath.Base(todayNote.FilePath))))
 	} else {
 		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("ℹ Today's note already exists: %s", filepath.Base(todayNote.FilePath))))
@@ -58,7 +91,7 @@ func runStart(cmd *cobra.Command, args []string) error {
 
 	// Process previous note if it exists
 	if previousNote != nil {
-		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("📄 Found previous note: %s", filepath.Base(previousNote.FilePath))))
+		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("📄 Found previous note: %s (%s · %s)", filepath.Base(previousNote.FilePath), previousNote.Date.Format("January 2, 2006"), ui.RelativeDate(previousNote.Date))))
 		fmt.Println()
 
 		// Review pending items from previous note
@@ -72,17 +105,8 @@ func runStart(cmd *cobra.Command, args []string) error {
 				return fmt.Errorf("error reviewing pending items: %w", err)
 			}
 
-			// Sort indices in descending order to avoid index shifting during removal
-			sort.Sort(sort.Reverse(sort.IntSlice(completedIndices)))
-
 			// Process completed items - move to previous note's completed section
-			for _, idx := range completedIndices {
-				item := previousNote.PendingWork[idx]
-				previousNote.CompletedWork = append(previousNote.CompletedWork, notes.WorkItem{
-					Text:      item.Text,
-					Completed: true,
-				})
-			}
+			recordCompleted(previousNote, completedIndices, today)
 
 			// Remaining pending items go to today's note
 			completedSet := make(map[int]bool)
@@ -90,13 +114,27 @@ func runStart(cmd *cobra.Command, args []string) error {
 				completedSet[idx] = true
 			}
 
+			var carried []notes.WorkItem
 			for i, item := range previousNote.PendingWork {
 				if !completedSet[i] {
-					// Add to today's pending
-					todayNote.AddPendingItem(item.Text)
+					carried = append(carried, item)
 				}
 			}
 
+			reviewed := len(carried)
+			carried, err = reviewStaleItems(carried, today)
+			if err != nil {
+				return fmt.Errorf("error reviewing stale items: %w", err)
+			}
+			recap.completed = len(completedIndices)
+			recap.carried = len(carried)
+			recap.dropped = reviewed - len(carried)
+
+			for _, item := range carried {
+				// Add to today's pending, keeping markers like pinned
+				todayNote.AppendPendingItem(item)
+			}
+
 			// Update previous note - clear pending (items either completed or moved)
 			previousNote.PendingWork = []notes.WorkItem{}
 
@@ -106,44 +144,33 @@ func runStart(cmd *cobra.Command, args []string) error {
 			}
 		}
 
-		// Generate summary if there's completed work
-		if previousNote.HasCompletedWork() {
-			fmt.Println()
-			fmt.Println(ui.HeaderStyle.Render("AI Summary"))
-			fmt.Println(ui.MutedStyle.Render("Generating summary of completed work..."))
-
-			// Test connection first
-			if err := aiClient.TestConnection(); err != nil {
-				fmt.Println(ui.RenderWarning(fmt.Sprintf("Could not connect to OpenCode server: %v", err)))
-				fmt.Println(ui.MutedStyle.Render("Skipping AI summary generation."))
-			} else {
-				summary, err := aiClient.SummarizeWorkItems(previousNote.CompletedWork)
-				if err != nil {
-					fmt.Println(ui.RenderWarning(fmt.Sprintf("Could not generate summary: %v", err)))
-				} else {
-					fmt.Println()
-					prompter.DisplaySummaryBox("Summary", summary)
-
-					// Update both notes with the summary
-					previousNote.Summary = summary
-					todayNote.YesterdaySummary = summary
-				}
+		// Generate summary if there's completed work and AI is enabled for this run
+		if summarize && previousNote.HasCompletedWork() {
+			if summary := generateSummary(cmd.Context(), client, previousNote); summary != "" {
+				// Update both notes with the summary
+				previousNote.Summary = summary
+				todayNote.YesterdaySummary = summary
+				recap.summarized = true
 			}
 		}
 
-		// Save the updated previous note
-		if err := writer.WriteNote(previousNote); err != nil {
-			return fmt.Errorf("error saving previous note: %w", err)
+		// Save the updated previous note, unless nothing in it changed
+		if saved, err := parser.ParseFile(previousNote.FilePath); err != nil || !previousNote.Equal(saved) {
+			if err := saveNote(previousNote); err != nil {
+				return fmt.Errorf("error saving previous note: %w", err)
+			}
+			fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("ℹ Updated: %s", filepath.Base(previousNote.FilePath))))
 		}
-		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("ℹ Updated: %s", filepath.Base(previousNote.FilePath))))
+		recap.previousPath = previousNote.FilePath
 	} else {
 		fmt.Println(ui.MutedStyle.Render("No previous notes found. Starting fresh!"))
 	}
 
 	// Save today's note
-	if err := writer.WriteNote(todayNote); err != nil {
+	if err := saveNote(todayNote); err != nil {
 		return fmt.Errorf("error saving today's note: %w", err)
 	}
+	recap.todayPath = todayNote.FilePath
 
 	fmt.Println()
 	fmt.Println(ui.RenderDivider(50))
@@ -153,9 +180,164 @@ func runStart(cmd *cobra.Command, args []string) error {
 	// Show current state
 	prompter.DisplayWorkItems(todayNote.PendingWork, todayNote.CompletedWork)
 
+	recordStart(time.Now())
+
+	recap.print()
 	fmt.Println(ui.RenderSuccess("Daily workflow complete!"))
 	fmt.Println(ui.MutedStyle.Render("Use 'worklog add \"task\"' to add new items"))
 	fmt.Println()
 
 	return nil
 }
+
+// startRecap records what a start run did, for the recap shown at the end
+type startRecap struct {
+	todayPath    string
+	created      bool
+	previousPath string
+	completed    int
+	carried      int
+	dropped      int
+	summarized   bool
+}
+
+// print shows the recap as a card
+func (r startRecap) print() {
+	todayAction := "Updated"
+	if r.created {
+		todayAction = "Created"
+	}
+	lines := []string{
+		ui.HeaderStyle.Render("Recap"),
+		fmt.Sprintf("%s %s", ui.MutedStyle.Render(fmt.Sprintf("%-15s", todayAction+" today:")), absPath(r.todayPath)),
+	}
+	if r.previousPath != "" {
+		lines = append(lines,
+			fmt.Sprintf("%s %s", ui.MutedStyle.Render(fmt.Sprintf("%-15s", "Previous note:")), absPath(r.previousPath)),
+			fmt.Sprintf("%s %d", ui.MutedStyle.Render(fmt.Sprintf("%-15s", "Marked done:")), r.completed),
+			fmt.Sprintf("%s %d", ui.MutedStyle.Render(fmt.Sprintf("%-15s", "Carried over:")), r.carried),
+		)
+		if r.dropped > 0 {
+			lines = append(lines, fmt.Sprintf("%s %d", ui.MutedStyle.Render(fmt.Sprintf("%-15s", "Dropped:")), r.dropped))
+		}
+	}
+	summary := "not generated"
+	if r.summarized {
+		summary = "generated"
+	}
+	lines = append(lines, fmt.Sprintf("%s %s", ui.MutedStyle.Render(fmt.Sprintf("%-15s", "AI summary:")), summary))
+
+	fmt.Println(ui.CardStyle.Render(strings.Join(lines, "\n")))
+}
+
+// staleActions are the choices offered for an item pending past STALE_THRESHOLD
+var staleActions = []string{"Keep", "Pin to top", "Drop"}
+
+// reviewStaleItems asks what to do with each carried item that has been
+// pending for more than STALE_THRESHOLD days, returning the items to carry
+func reviewStaleItems(items []notes.WorkItem, today time.Time) ([]notes.WorkItem, error) {
+	if cfg.StaleThreshold == 0 {
+		return items, nil
+	}
+
+	var kept []notes.WorkItem
+	dropped := 0
+	announced := false
+	for _, item := range items {
+		days := pendingDays(item, today)
+		if days <= cfg.StaleThreshold {
+			kept = append(kept, item)
+			continue
+		}
+
+		if !announced {
+			fmt.Println()
+			fmt.Println(ui.RenderWarning(fmt.Sprintf("Some items have been pending for more than %d days", cfg.StaleThreshold)))
+			announced = true
+		}
+
+		choice, err := prompter.SelectFromList(fmt.Sprintf("\"%s\" pending for %d days", item.Text, days), staleActions)
+		if err != nil {
+			return nil, err
+		}
+		switch staleActions[choice] {
+		case "Pin to top":
+			item.Pinned = true
+			kept = append(kept, item)
+		case "Drop":
+			dropped++
+		default:
+			kept = append(kept, item)
+		}
+	}
+
+	if dropped > 0 {
+		fmt.Println(ui.RenderInfo(fmt.Sprintf("Dropped %d stale item(s)", dropped)))
+	}
+	return kept, nil
+}
+
+// pendingDays returns how many days an item has been pending, or 0 when its
+// created date is unknown
+func pendingDays(item notes.WorkItem, today time.Time) int {
+	if item.CreatedAt.IsZero() {
+		return 0
+	}
+	return int(today.Sub(item.CreatedAt.Truncate(24*time.Hour)).Hours() / 24)
+}
+
+// lastStartPath returns the file recording when start last ran for the
+// configured workplace, or "" if there is no cache directory
+func lastStartPath() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(cacheDir, "worklog", "last-start-"+cfg.WorkplaceName)
+}
+
+// recordStart remembers when start ran, for summarize --since-last-start.
+// Failures are ignored; summarize then falls back to today.
+func recordStart(t time.Time) {
+	path := lastStartPath()
+	if path == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, []byte(t.Format(time.RFC3339)), 0644)
+}
+
+// lastStart returns when start last ran for the configured workplace
+func lastStart() (time.Time, bool) {
+	path := lastStartPath()
+	if path == "" {
+		return time.Time{}, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// recordCompleted appends the pending items at indices to the note's completed
+// section in their note order, whatever order they were selected in, skipping
+// any already recorded there by an earlier run. Each is given a done date of
+// date, the day it was recorded, so diff can tell it from the note's own day.
+func recordCompleted(note *notes.Note, indices []int, date time.Time) {
+	for _, idx := range slices.Sorted(slices.Values(indices)) {
+		item := note.PendingWork[idx]
+		if note.HasCompletedItem(item.Text) {
+			continue
+		}
+		item.Completed = true
+		item.CompletedAt = date
+		note.CompletedWork = append(note.CompletedWork, item)
+	}
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
+)
+
+func TestRecordCompletedTwice(t *testing.T) {
+	note := notes.NewNote(time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC), "Work")
+	note.PendingWork = []notes.WorkItem{
+		{Text: "Review PR"},
+		{Text: "Write docs"},
+		{Text: "Fix flaky test"},
+	}
+
+	recordCompleted(note, []int{0, 2}, note.Date.AddDate(0, 0, 1))
+	first := slices.Clone(note.CompletedWork)
+	if len(first) != 2 || first[0].Text != "Review PR" || first[1].Text != "Fix flaky test" || !first[0].Completed {
+		t.Fatalf("completed after first run = %+v", first)
+	}
+
+	// A rerun after an interrupted start selects the same items again
+	recordCompleted(note, []int{0, 2}, note.Date.AddDate(0, 0, 1))
+	if !slices.Equal(note.CompletedWork, first) {
+		t.Errorf("second run changed completed items: %+v, want %+v", note.CompletedWork, first)
+	}
+}
+
+func TestRecordCompletedNoteOrder(t *testing.T) {
+	note := notes.NewNote(time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC), "Work")
+	note.PendingWork = []notes.WorkItem{
+		{Text: "Review PR"},
+		{Text: "Write docs"},
+		{Text: "Fix flaky test"},
+	}
+
+	// Items selected out of order are still recorded top to bottom
+	recordCompleted(note, []int{2, 0, 1}, note.Date.AddDate(0, 0, 1))
+
+	var got []string
+	for _, item := range note.CompletedWork {
+		got = append(got, item.Text)
+	}
+	if want := []string{"Review PR", "Write docs", "Fix flaky test"}; !slices.Equal(got, want) {
+		t.Errorf("completed = %q, want %q", got, want)
+	}
+}
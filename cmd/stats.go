@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
+	"github.com/sandepten/work-obsidian-noter/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsFrom  string
+	statsTo    string
+	statsByTag bool
+	statsJSON  bool
+	statsAll   bool
+	statsHeat  bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show work statistics over a date range",
+	Long: `Show pending and completed work statistics over a date range.
+
+By default the last 7 days (including today) are included. Use --from and
+--to to choose a different range, and --by-tag to tally completed items by
+their inline #tags. Use --json for machine-readable output.
+
+Use --all-time for lifetime totals across every note: notes written, tasks
+completed, first and last note dates and the most productive day. Use
+--heatmap for a grid of completed items per day over the last year.`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+func init() {
+	statsCmd.Flags().StringVar(&statsFrom, "from", "", "Start date (YYYY-MM-DD, default 6 days ago)")
+	statsCmd.Flags().StringVar(&statsTo, "to", "", "End date, inclusive (YYYY-MM-DD, default today)")
+	statsCmd.Flags().BoolVar(&statsByTag, "by-tag", false, "Tally completed items by inline #tag")
+	statsCmd.Flags().BoolVar(&statsJSON, "json", false, "Output the stats as JSON")
+	statsCmd.Flags().BoolVar(&statsAll, "all-time", false, "Show lifetime totals across every note")
+	statsCmd.Flags().BoolVar(&statsHeat, "heatmap", false, "Show a grid of completed items per day over the last year")
+	rootCmd.AddCommand(statsCmd)
+}
+
+// statsReport is the output of the stats command. Its JSON form is consumed
+// by external tools, so fields may be added but not renamed or removed.
+type statsReport struct {
+	Workplace      string     `json:"workplace"`
+	From           string     `json:"from"`
+	To             string     `json:"to"`
+	Notes          int        `json:"notes"`
+	Pending        int        `json:"pending"`
+	Completed      int        `json:"completed"`
+	CompletionRate float64    `json:"completion_rate"`
+	Days           []statsDay `json:"days"`
+	Tags           []tagCount `json:"tags,omitempty"`
+}
+
+// statsDay holds the counts for a single note in the stats range
+type statsDay struct {
+	Workplace string `json:"workplace"`
+	Date      string `json:"date"`
+	Pending   int    `json:"pending"`
+	Completed int    `json:"completed"`
+
+	date time.Time
+}
+
+// tagCount is a tag and the number of completed items carrying it
+type tagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// allTimeReport is the output of stats --all-time. Like statsReport, its JSON
+// form may gain fields but existing ones keep their names.
+type allTimeReport struct {
+	Workplace        string  `json:"workplace"`
+	Notes            int     `json:"notes"`
+	Completed        int     `json:"completed"`
+	Pending          int     `json:"pending"`
+	FirstNote        string  `json:"first_note,omitempty"`
+	LastNote         string  `json:"last_note,omitempty"`
+	BestDay          string  `json:"best_day,omitempty"`
+	BestDayCompleted int     `json:"best_day_completed"`
+	AveragePerNote   float64 `json:"average_per_note"`
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	today := time.Now().Truncate(24 * time.Hour)
+
+	if statsHeat {
+		if statsFrom != "" || statsTo != "" || statsByTag || statsAll || statsJSON {
+			return fmt.Errorf("--heatmap cannot be combined with other stats flags")
+		}
+		return runHeatmap(today)
+	}
+
+	if statsAll {
+		if statsFrom != "" || statsTo != "" || statsByTag {
+			return fmt.Errorf("--all-time cannot be combined with --from, --to or --by-tag")
+		}
+		return runAllTimeStats()
+	}
+
+	to := today
+	if statsTo != "" {
+		var err error
+		if to, err = parseDate(statsTo); err != nil {
+			return err
+		}
+	}
+
+	from := to.AddDate(0, 0, -6)
+	if statsFrom != "" {
+		var err error
+		if from, err = parseDate(statsFrom); err != nil {
+			return err
+		}
+	}
+
+	if from.After(to) {
+		return fmt.Errorf("--from must not be after --to")
+	}
+
+	rangeNotes, err := parser.FindNotesInRange(from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return fmt.Errorf("error finding notes: %w", err)
+	}
+
+	report := buildStatsReport(rangeNotes, from, to)
+
+	if statsJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.TitleStyle.Render("📈 Work Stats"))
+	fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("%s – %s", from.Format("Jan 2, 2006"), to.Format("Jan 2, 2006"))))
+	fmt.Println(ui.RenderDivider(50))
+	fmt.Println()
+
+	if report.Notes == 0 {
+		fmt.Println(ui.RenderEmptyState("  No notes found in this range"))
+		fmt.Println()
+		return nil
+	}
+
+	if statsByTag {
+		fmt.Println(ui.HeaderStyle.Render("Completed by Tag"))
+		if len(report.Tags) == 0 {
+			fmt.Println(ui.RenderEmptyState("  No completed items in this range"))
+		}
+		for _, tc := range report.Tags {
+			fmt.Printf("  %-24s %s\n", tc.Tag, ui.CompletedItemStyle.Render(fmt.Sprintf("%d", tc.Count)))
+		}
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Println(ui.HeaderStyle.Render("Per Day"))
+	for _, day := range report.Days {
+		fmt.Printf("  %s  %s  %s\n",
+			ui.MutedStyle.Render(day.date.Format("Mon, Jan 2")),
+			ui.PendingItemStyle.Render(fmt.Sprintf("%2d pending", day.Pending)),
+			ui.CompletedItemStyle.Render(fmt.Sprintf("%2d done", day.Completed)))
+	}
+	fmt.Println()
+
+	fmt.Println(ui.HeaderStyle.Render("Totals"))
+	fmt.Printf("  %s %d\n", ui.MutedStyle.Render("Notes:    "), report.Notes)
+	fmt.Printf("  %s %d\n", ui.MutedStyle.Render("Completed:"), report.Completed)
+	fmt.Printf("  %s %d\n", ui.MutedStyle.Render("Pending:  "), report.Pending)
+	fmt.Printf("  %s %.0f%%\n", ui.MutedStyle.Render("Rate:     "), report.CompletionRate*100)
+	fmt.Println()
+
+	return nil
+}
+
+// buildStatsReport aggregates per-day counts, totals and (with --by-tag) tag tallies
+func buildStatsReport(rangeNotes []*notes.Note, from, to time.Time) statsReport {
+	report := statsReport{
+		Workplace: cfg.WorkplaceName,
+		From:      from.Format("2006-01-02"),
+		To:        to.Format("2006-01-02"),
+		Notes:     len(rangeNotes),
+		Days:      []statsDay{},
+	}
+
+	tagTotals := make(map[string]int)
+	for _, note := range rangeNotes {
+		report.Pending += len(note.PendingWork)
+		report.Completed += len(note.CompletedWork)
+		report.Days = append(report.Days, statsDay{
+			Workplace: note.Workplace,
+			Date:      note.Date.Format("2006-01-02"),
+			Pending:   len(note.PendingWork),
+			Completed: len(note.CompletedWork),
+			date:      note.Date,
+		})
+
+		if statsByTag {
+			for _, item := range note.CompletedWork {
+				tags := item.Tags()
+				if len(tags) == 0 {
+					tagTotals["(untagged)"]++
+				}
+				for _, tag := range tags {
+					tagTotals["#"+tag]++
+				}
+			}
+		}
+	}
+
+	if total := report.Pending + report.Completed; total > 0 {
+		report.CompletionRate = float64(report.Completed) / float64(total)
+	}
+
+	for tag, count := range tagTotals {
+		report.Tags = append(report.Tags, tagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(report.Tags, func(i, j int) bool {
+		if report.Tags[i].Count != report.Tags[j].Count {
+			return report.Tags[i].Count > report.Tags[j].Count
+		}
+		return report.Tags[i].Tag < report.Tags[j].Tag
+	})
+
+	return report
+}
+
+// runAllTimeStats shows lifetime totals. Notes are parsed one at a time so
+// only the running totals are kept in memory.
+func runAllTimeStats() error {
+	files, err := parser.ListNoteFiles()
+	if err != nil {
+		return fmt.Errorf("error listing notes: %w", err)
+	}
+
+	report := allTimeReport{Workplace: cfg.WorkplaceName, Notes: len(files)}
+	for i, file := range files {
+		note, err := parser.ParseFile(file.Path)
+		if err != nil {
+			return fmt.Errorf("error reading note: %w", err)
+		}
+
+		completed := len(note.CompletedWork)
+		report.Completed += completed
+		if completed > report.BestDayCompleted {
+			report.BestDay = file.Date.Format("2006-01-02")
+			report.BestDayCompleted = completed
+		}
+		// Pending items are carried forward, so only the latest note's count is current
+		if i == len(files)-1 {
+			report.Pending = len(note.PendingWork)
+		}
+	}
+	if len(files) > 0 {
+		report.FirstNote = files[0].Date.Format("2006-01-02")
+		report.LastNote = files[len(files)-1].Date.Format("2006-01-02")
+		report.AveragePerNote = float64(report.Completed) / float64(len(files))
+	}
+
+	if statsJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.TitleStyle.Render("📈 All-Time Stats"))
+	fmt.Println(ui.MutedStyle.Render(cfg.WorkplaceName))
+	fmt.Println(ui.RenderDivider(50))
+	fmt.Println()
+
+	if report.Notes == 0 {
+		fmt.Println(ui.RenderEmptyState("  No notes yet"))
+		fmt.Println()
+		return nil
+	}
+
+	first, _ := time.Parse("2006-01-02", report.FirstNote)
+	last, _ := time.Parse("2006-01-02", report.LastNote)
+	fmt.Printf("  %s %d\n", ui.MutedStyle.Render("Notes:        "), report.Notes)
+	fmt.Printf("  %s %s\n", ui.MutedStyle.Render("First note:   "), first.Format("Jan 2, 2006"))
+	fmt.Printf("  %s %s\n", ui.MutedStyle.Render("Last note:    "), last.Format("Jan 2, 2006"))
+	fmt.Printf("  %s %s\n", ui.MutedStyle.Render("Completed:    "), ui.CompletedItemStyle.Render(fmt.Sprintf("%d", report.Completed)))
+	fmt.Printf("  %s %.1f per note\n", ui.MutedStyle.Render("Average:      "), report.AveragePerNote)
+	if report.BestDay != "" {
+		best, _ := time.Parse("2006-01-02", report.BestDay)
+		fmt.Printf("  %s %s (%d done)\n", ui.MutedStyle.Render("Best day:     "), best.Format("Mon, Jan 2, 2006"), report.BestDayCompleted)
+	}
+	fmt.Printf("  %s %s\n", ui.MutedStyle.Render("Still pending:"), ui.PendingItemStyle.Render(fmt.Sprintf("%d", report.Pending)))
+	fmt.Println()
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
+	"github.com/spf13/cobra"
+)
+
+var statusFormat string
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Print a one-line status for prompts and status bars",
+	Long: `Print a compact, undecorated one-line summary of today's note,
+suitable for shell prompts or tmux status bars.
+
+Use --format to customize the output. Available placeholders:
+  {pending}    number of pending items
+  {done}       number of completed items
+  {workplace}  the workplace name`,
+	Args: cobra.NoArgs,
+	RunE: runStatus,
+}
+
+func init() {
+	statusCmd.Flags().StringVarP(&statusFormat, "format", "f", "{workplace}: {pending} pending, {done} done", "Output template")
+	rootCmd.AddCommand(statusCmd)
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	today := time.Now().Truncate(24 * time.Hour)
+
+	pending, done := 0, 0
+	todayNote, err := parser.FindTodayNote(today)
+	if err != nil && !errors.Is(err, notes.ErrNoteNotFound) {
+		return fmt.Errorf("error finding today's note: %w", err)
+	}
+	if todayNote != nil {
+		pending = len(todayNote.PendingWork)
+		done = len(todayNote.CompletedWork)
+	}
+
+	replacer := strings.NewReplacer(
+		"{pending}", strconv.Itoa(pending),
+		"{done}", strconv.Itoa(done),
+		"{workplace}", cfg.WorkplaceName,
+	)
+	fmt.Println(replacer.Replace(statusFormat))
+
+	return nil
+}
@@ -1,55 +1,157 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
+	"github.com/sandepten/work-obsidian-noter/internal/summarizer"
 	"github.com/sandepten/work-obsidian-noter/internal/ui"
 	"github.com/spf13/cobra"
 )
 
+var (
+	summarizeWait    time.Duration
+	summarizeVerbose bool
+	summarizePrompt  bool
+	summarizeSelect  bool
+	summarizeItems   []string
+	summarizeSince   bool
+)
+
 var summarizeCmd = &cobra.Command{
 	Use:   "summarize",
 	Short: "Get AI summary of today's completed work",
-	Long:  `Generate and display an AI-powered summary of today's completed work items.`,
-	RunE:  runSummarize,
+	Long: `Generate and display an AI-powered summary of today's completed work items.
+
+Use --select to choose which completed items to include, and --print-prompt
+to see the exact prompt that would be sent, without contacting the AI server.
+
+Use --item, once per item, to summarize the given text instead of a note.
+This is handy for trying out prompt and model settings.
+
+Use --since-last-start to summarize everything completed in the notes from
+the day 'worklog start' last ran through today. Without a recorded start,
+today's note is used.`,
+	RunE: runSummarize,
 }
 
 func init() {
+	summarizeCmd.Flags().DurationVar(&summarizeWait, "wait", 0, "How long to wait for the AI response, e.g. 3m (default from AI_WAIT)")
+	summarizeCmd.Flags().BoolVar(&summarizeSelect, "select", false, "Choose which completed items to include in the summary")
+	summarizeCmd.Flags().StringArrayVar(&summarizeItems, "item", nil, "Summarize this item text instead of today's note (repeatable)")
+	summarizeCmd.Flags().BoolVar(&summarizeSince, "since-last-start", false, "Summarize work completed since 'worklog start' last ran")
+	summarizeCmd.Flags().BoolVar(&summarizePrompt, "print-prompt", false, "Print the prompt that would be sent and exit without calling the AI server")
+	summarizeCmd.Flags().BoolVarP(&summarizeVerbose, "verbose", "v", false, "Print timing for each phase of the AI request")
+	addAIOverrideFlags(summarizeCmd)
 	rootCmd.AddCommand(summarizeCmd)
 }
 
 func runSummarize(cmd *cobra.Command, args []string) error {
 	today := time.Now().Truncate(24 * time.Hour)
 
-	// Get today's note
-	todayNote, err := parser.FindTodayNote(today)
+	if len(summarizeItems) > 0 && (summarizeSelect || summarizeSince) {
+		return fmt.Errorf("--item cannot be combined with --select or --since-last-start")
+	}
+
+	client, err := summarizerFor(cmd)
 	if err != nil {
-		return fmt.Errorf("error finding today's note: %w", err)
+		return err
 	}
 
-	if todayNote == nil {
-		prompter.DisplayWarning("No note found for today. Use 'worklog start' to create one.")
+	if client == nil {
+		prompter.DisplayWarning("AI is disabled (AI_ENABLED=false or --no-ai). Nothing to summarize.")
 		return nil
 	}
 
-	if !todayNote.HasCompletedWork() {
-		fmt.Println()
-		fmt.Println(ui.MutedStyle.Render("No completed work items to summarize."))
-		fmt.Println(ui.MutedStyle.Render("Use 'worklog done' to mark items as completed first."))
+	if cmd.Flags().Changed("wait") {
+		if summarizeWait <= 0 {
+			return fmt.Errorf("--wait must be a positive duration")
+		}
+		client.SetWaitTimeout(summarizeWait)
+	}
+
+	if summarizeVerbose {
+		client.SetLogger(func(format string, args ...any) {
+			fmt.Println(ui.MutedStyle.Render("  ⏱ " + fmt.Sprintf(format, args...)))
+		})
+	}
+
+	var items []notes.WorkItem
+	var info *summarizer.SummarizeContext
+	from := today
+	if len(summarizeItems) > 0 {
+		// Explicit items are not tied to a day, so no note is read and no
+		// date context is sent
+		for _, text := range summarizeItems {
+			if text = strings.TrimSpace(text); text != "" {
+				items = append(items, notes.WorkItem{Text: text, Completed: true})
+			}
+		}
+		if len(items) == 0 {
+			return fmt.Errorf("--item text cannot be empty")
+		}
+	} else {
+		if summarizeSince {
+			if started, ok := lastStart(); ok {
+				from = started.Truncate(24 * time.Hour)
+			} else {
+				prompter.DisplayMessage("No 'worklog start' run recorded yet; summarizing today.")
+			}
+		}
+		var err error
+		if items, err = completedItemsSince(from, today); err != nil || items == nil {
+			return err
+		}
+		info = dayContext(from, today, items)
+	}
+
+	if summarizeSelect {
 		fmt.Println()
+		fmt.Println(ui.HeaderStyle.Render("Choose Items to Summarize"))
+		indices, err := prompter.SelectItemsToSummarize(items)
+		if err != nil {
+			return fmt.Errorf("error selecting items: %w", err)
+		}
+		if len(indices) == 0 {
+			fmt.Println()
+			fmt.Println(ui.MutedStyle.Render("No items selected. Nothing to summarize."))
+			fmt.Println()
+			return nil
+		}
+		var selected []notes.WorkItem
+		for _, idx := range indices {
+			selected = append(selected, items[idx])
+		}
+		items = selected
+		info = dayContext(from, today, items)
+	}
+
+	if summarizePrompt {
+		if system := client.SystemPrompt(); system != "" {
+			fmt.Printf("System: %s\n\n", system)
+		}
+		fmt.Print(client.WorkItemsPrompt(items, info))
 		return nil
 	}
 
 	fmt.Println()
 	fmt.Println(ui.TitleStyle.Render("📊 Work Summary"))
-	fmt.Println(ui.MutedStyle.Render(today.Format("Monday, January 2, 2006")))
+	if len(summarizeItems) > 0 {
+		fmt.Println(ui.MutedStyle.Render("Items given with --item"))
+	} else if from.Before(today) {
+		fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("%s – %s", from.Format("Monday, January 2"), today.Format("Monday, January 2, 2006"))))
+	} else {
+		fmt.Println(ui.MutedStyle.Render(today.Format("Monday, January 2, 2006")))
+	}
 	fmt.Println(ui.RenderDivider(50))
 	fmt.Println()
 
 	// Display completed work
 	fmt.Println(ui.HeaderStyle.Render("Completed Work"))
-	for i, item := range todayNote.CompletedWork {
+	for i, item := range items {
 		fmt.Println(ui.RenderCompletedItem(i+1, item.Text))
 	}
 	fmt.Println()
@@ -58,17 +160,67 @@ func runSummarize(cmd *cobra.Command, args []string) error {
 	fmt.Println(ui.InfoStyle.Render("🤖 Generating AI summary..."))
 	fmt.Println()
 
+	// Ctrl+C cancels the request rather than killing the process outright
+	ctx, stop := interruptible(cmd.Context())
+	defer stop()
+
 	// Test connection first
-	if err := aiClient.TestConnection(); err != nil {
+	if err := client.TestConnection(ctx); err != nil {
 		return fmt.Errorf("could not connect to OpenCode server: %w", err)
 	}
 
-	summary, err := aiClient.SummarizeWorkItems(todayNote.CompletedWork)
+	summary, err := client.SummarizeWorkItems(ctx, items, info)
 	if err != nil {
 		return fmt.Errorf("could not generate summary: %w", err)
 	}
 
 	prompter.DisplaySummaryBox("AI-Generated Summary", summary)
+	printModelFooter(client)
 
 	return nil
 }
+
+// completedItemsSince returns the completed items in the notes from from
+// through today, or nil after explaining why there is nothing to summarize
+func completedItemsSince(from, today time.Time) ([]notes.WorkItem, error) {
+	var items []notes.WorkItem
+	found := false
+	for date := from; !date.After(today); date = date.AddDate(0, 0, 1) {
+		note, err := parser.FindTodayNote(date)
+		if errors.Is(err, notes.ErrNoteNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error finding note: %w", err)
+		}
+		found = true
+		items = append(items, note.CompletedWork...)
+	}
+
+	if !found {
+		if from.Before(today) {
+			prompter.DisplayWarning(fmt.Sprintf("No notes found since %s.", from.Format("Mon, Jan 2")))
+		} else {
+			prompter.DisplayWarning("No note found for today. Use 'worklog start' to create one.")
+		}
+		return nil, nil
+	}
+
+	if len(items) == 0 {
+		fmt.Println()
+		fmt.Println(ui.MutedStyle.Render("No completed work items to summarize."))
+		fmt.Println(ui.MutedStyle.Render("Use 'worklog done' to mark items as completed first."))
+		fmt.Println()
+		return nil, nil
+	}
+	return items, nil
+}
+
+// dayContext returns the summary context for items from a single day; items
+// gathered over several days get none, since it describes one date
+func dayContext(from, today time.Time, items []notes.WorkItem) *summarizer.SummarizeContext {
+	if from.Before(today) {
+		return nil
+	}
+	return summarizeContext(today, items)
+}
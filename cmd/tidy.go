@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
+	"github.com/sandepten/work-obsidian-noter/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tidyDate string
+	tidyAll  bool
+	tidyYes  bool
+)
+
+var tidyCmd = &cobra.Command{
+	Use:   "tidy",
+	Short: "Normalize the formatting of notes",
+	Long: `Re-render today's note, another day's with --date, or every note with
+--all in the canonical format: frontmatter, title, summary fields and task
+sections in their usual order, with consistent blank lines and checkboxes.
+
+Notes are rewritten the same way every other command writes them. A note
+holding text that would not survive, such as paragraphs outside the task
+sections or unknown frontmatter fields, is skipped with a warning listing
+those lines. Notes already in the canonical format are left untouched, and
+finalized notes are skipped unless --force is passed.
+
+--all asks for confirmation first unless --yes is passed. Use --dry-run to
+list the notes that would change.`,
+	Args: cobra.NoArgs,
+	RunE: runTidy,
+}
+
+func init() {
+	tidyCmd.Flags().StringVarP(&tidyDate, "date", "d", "", "Tidy the note for this date (YYYY-MM-DD, default today)")
+	tidyCmd.Flags().BoolVar(&tidyAll, "all", false, "Tidy every note for the configured workplace")
+	tidyCmd.Flags().BoolVarP(&tidyYes, "yes", "y", false, "Tidy every note with --all without confirmation")
+	addForceFlag(tidyCmd)
+	addDryRunFlag(tidyCmd)
+	rootCmd.AddCommand(tidyCmd)
+}
+
+func runTidy(cmd *cobra.Command, args []string) error {
+	if tidyAll && tidyDate != "" {
+		return fmt.Errorf("--all cannot be combined with --date")
+	}
+
+	var files []notes.NoteFile
+	if tidyAll {
+		all, err := parser.ListNoteFiles()
+		if err != nil {
+			return fmt.Errorf("error listing notes: %w", err)
+		}
+		for _, file := range all {
+			if strings.EqualFold(file.Workplace(), cfg.WorkplaceName) {
+				files = append(files, file)
+			}
+		}
+	} else {
+		date := time.Now().Truncate(24 * time.Hour)
+		if tidyDate != "" {
+			var err error
+			if date, err = parseDate(tidyDate); err != nil {
+				return err
+			}
+		}
+		if !parser.NoteExists(date) {
+			prompter.DisplayWarning(fmt.Sprintf("No note found for %s.", date.Format("Mon, Jan 2, 2006")))
+			return nil
+		}
+		files = append(files, notes.NoteFile{Path: parser.NotePath(date), Date: date})
+	}
+
+	// A dry run changes nothing, so there is nothing to confirm
+	if tidyAll && !tidyYes && !dryRun && len(files) > 0 {
+		confirmed, err := prompter.ConfirmAction(fmt.Sprintf("Rewrite all %d note(s) for %s", len(files), cfg.WorkplaceName))
+		if err != nil {
+			return fmt.Errorf("error confirming tidy: %w", err)
+		}
+		if !confirmed {
+			fmt.Println(ui.MutedStyle.Render("Tidy cancelled. No changes made."))
+			return nil
+		}
+	}
+
+	tidied, skipped := 0, 0
+	for _, file := range files {
+		changed, skip, err := tidyNote(file.Path, file.Date)
+		if err != nil {
+			return err
+		}
+		if skip {
+			skipped++
+		}
+		if changed {
+			tidied++
+			if !dryRun {
+				fmt.Println(ui.RenderSuccess("Tidied " + filepath.Base(file.Path)))
+			}
+		}
+	}
+
+	if skipped > 0 {
+		fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("Skipped %d note(s).", skipped)))
+	}
+	switch {
+	case tidied == 0 && skipped > 0:
+		prompter.DisplayMessage("No notes tidied.")
+	case tidied == 0:
+		prompter.DisplayMessage(fmt.Sprintf("All %d note(s) are already tidy.", len(files)))
+	case dryRun:
+		fmt.Println(ui.RenderInfo(fmt.Sprintf("Dry run: %d of %d note(s) would be tidied", tidied, len(files))))
+	default:
+		fmt.Println(ui.RenderInfo(fmt.Sprintf("Tidied %d of %d note(s)", tidied, len(files))))
+	}
+	return nil
+}
+
+// tidyNote rewrites the note at path, dated date, in the canonical format,
+// reporting whether its content changed. A note that rewriting would lose
+// content from, or a finalized one under --all, is skipped with a warning.
+func tidyNote(path string, date time.Time) (changed, skipped bool, err error) {
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return false, false, fmt.Errorf("error reading note: %w", err)
+	}
+
+	note, err := parser.ParseFile(path)
+	if err != nil {
+		return false, false, fmt.Errorf("error parsing note: %w", err)
+	}
+	if err := checkNotFinalized(note); err != nil {
+		if !tidyAll {
+			return false, false, err
+		}
+		fmt.Println(ui.RenderWarning(fmt.Sprintf("Skipped %s (finalized)", filepath.Base(path))))
+		return false, true, nil
+	}
+
+	// Fill in fields a hand-made note may lack from a fresh note for its date
+	fresh := notes.NewNote(date, cfg.WorkplaceName)
+	if note.ID == "" {
+		note.ID = fresh.ID
+	}
+	if len(note.Tags) == 0 {
+		note.Tags = fresh.Tags
+	}
+	if note.Date.IsZero() {
+		note.Date = fresh.Date
+	}
+	if note.Title == "" {
+		note.Title = fresh.Title
+	}
+
+	var rendered bytes.Buffer
+	if err := writer.Render(&rendered, note); err != nil {
+		return false, false, fmt.Errorf("error rendering note: %w", err)
+	}
+	if bytes.Equal(original, rendered.Bytes()) {
+		return false, false, nil
+	}
+
+	if dropped := notes.DroppedLines(string(original), rendered.String()); len(dropped) > 0 {
+		fmt.Println(ui.RenderWarning(fmt.Sprintf("Skipped %s: tidying would drop %d line(s)", filepath.Base(path), len(dropped))))
+		for _, line := range dropped {
+			fmt.Println(ui.MutedStyle.Render("  " + line))
+		}
+		return false, true, nil
+	}
+
+	if err := saveNote(note); err != nil {
+		return false, false, fmt.Errorf("error saving note: %w", err)
+	}
+	return true, false, nil
+}
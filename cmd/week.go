@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sandepten/work-obsidian-noter/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	weekDate       string
+	weekStart      string
+	weekFromStored bool
+)
+
+var weekCmd = &cobra.Command{
+	Use:   "week",
+	Short: "Show the work completed this week",
+	Long: `Show completed work for each day of the current week.
+
+Weeks start on Monday by default. Set WEEK_START in the config or pass
+--week-start to use a different first day (e.g. sunday). Use --date to show
+the week containing another date.
+
+Use --from-stored to have the AI write a short overview of the week from each
+day's stored summary (or its completed items, for days without one), rather
+than re-summarizing every item.`,
+	Args: cobra.NoArgs,
+	RunE: runWeek,
+}
+
+func init() {
+	weekCmd.Flags().StringVarP(&weekDate, "date", "d", "", "Any date within the week to show (YYYY-MM-DD, default today)")
+	weekCmd.Flags().StringVar(&weekStart, "week-start", "", "First day of the week: monday, sunday or saturday (default from WEEK_START)")
+	weekCmd.Flags().BoolVar(&weekFromStored, "from-stored", false, "Add an AI overview of the week built from the stored daily summaries")
+	rootCmd.AddCommand(weekCmd)
+}
+
+func runWeek(cmd *cobra.Command, args []string) error {
+	date := time.Now().Truncate(24 * time.Hour)
+	if weekDate != "" {
+		var err error
+		if date, err = parseDate(weekDate); err != nil {
+			return err
+		}
+	}
+
+	startName := cfg.WeekStart
+	if weekStart != "" {
+		startName = weekStart
+	}
+	startDay, err := parseWeekStart(startName)
+	if err != nil {
+		return err
+	}
+
+	from, to := weekBounds(date, startDay)
+	weekNotes, err := parser.FindNotesInRange(from, to)
+	if err != nil {
+		return fmt.Errorf("error finding notes: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.TitleStyle.Render("📆 Weekly Worklog"))
+	fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("%s – %s", from.Format("Mon, Jan 2"), to.AddDate(0, 0, -1).Format("Mon, Jan 2, 2006"))))
+	fmt.Println(ui.RenderDivider(50))
+	fmt.Println()
+
+	if len(weekNotes) == 0 {
+		fmt.Println(ui.RenderEmptyState("  No notes found for this week"))
+		fmt.Println()
+		return nil
+	}
+
+	totalCompleted := 0
+	for _, note := range weekNotes {
+		totalCompleted += len(note.CompletedWork)
+
+		fmt.Println(ui.HeaderStyle.Render(note.Date.Format("Monday, Jan 2")) + " " + ui.RenderBadge(len(note.CompletedWork), ui.CompletedBadgeStyle))
+		if note.Summary != "" {
+			fmt.Println(ui.RenderSummary("Summary", note.Summary))
+		}
+		if len(note.CompletedWork) == 0 {
+			fmt.Println(ui.RenderEmptyState("  No completed items"))
+		}
+		for i, item := range note.CompletedWork {
+			fmt.Println(ui.RenderCompletedItem(i+1, item.Text))
+		}
+		fmt.Println()
+	}
+
+	fmt.Println(ui.RenderDivider(50))
+	fmt.Println(ui.RenderSuccess(fmt.Sprintf("%d item(s) completed across %d day(s)", totalCompleted, len(weekNotes))))
+	fmt.Println()
+
+	if weekFromStored {
+		if aiClient == nil {
+			prompter.DisplayWarning("AI is disabled (AI_ENABLED=false or --no-ai). Skipping week overview.")
+			return nil
+		}
+
+		fmt.Println(ui.HeaderStyle.Render("Week Overview"))
+		fmt.Println(ui.MutedStyle.Render("Combining stored daily summaries..."))
+		runSummary(cmd.Context(), aiClient, "Week", func(ctx context.Context) (string, error) {
+			return aiClient.SummarizeWeek(ctx, weekNotes)
+		})
+		fmt.Println()
+	}
+
+	return nil
+}
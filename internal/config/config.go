@@ -2,9 +2,14 @@ package config
 
 import (
 	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Config holds the application configuration
@@ -14,30 +19,115 @@ type Config struct {
 	OpenCodeServer    string
 	AIProvider        string
 	AIModel           string
+	AIEnabled         bool
+	AIHealthPath      string
+	AIWait            time.Duration
+	AIPollInterval    time.Duration
+	AIPersona         string
+	AISystemPrompt    string
+	AIIncludeContext  bool
+	WeekStart         string
+	CompletedInline   bool
+	LineEndings       string
+	TagsStyle         string
+	CheckboxCase      string
+	IconPending       string
+	IconDone          string
+	StaleThreshold    int
+	ListMaxItems      int
+	GitAutoCommit     bool
+	Aliases           string
+	WorkplaceSubdir   bool
+	AddPosition       string
+	VaultDetect       bool
 }
 
-// Load reads the configuration from ~/.config/worklog/config
+// pathKeys lists the config keys whose values are file paths and have ~ expanded
+var pathKeys = map[string]bool{
+	"WORK_NOTES_LOCATION": true,
+}
+
+// expandKeys lists the config keys whose values have $VAR references
+// expanded: the paths and the server URL. Free text such as AI_PERSONA is
+// left alone, so a literal $ survives.
+var expandKeys = map[string]bool{
+	"WORK_NOTES_LOCATION": true,
+	"OPENCODE_SERVER":     true,
+}
+
+// Load reads the configuration from ~/.config/worklog/config. Values that
+// fail to parse are replaced by their defaults and reported together in the
+// returned error, alongside a config that is still usable.
 func Load() (*Config, error) {
 	// Load config from ~/.config/worklog/config
-	configPath := getConfigPath()
+	configPath := Path()
 	loadConfigFile(configPath)
 
+	var errs []error
+	boolEnv := recorder[bool](&errs)
+	intEnv := recorder[int](&errs)
+	durationEnv := recorder[time.Duration](&errs)
+
 	cfg := &Config{
 		WorkNotesLocation: getEnv("WORK_NOTES_LOCATION", "~/Documents/obsidian-notes/Inbox/work"),
 		WorkplaceName:     getEnv("WORKPLACE_NAME", "Work"),
 		OpenCodeServer:    getEnv("OPENCODE_SERVER", "http://127.0.0.1:4096"),
 		AIProvider:        getEnv("AI_PROVIDER", "github-copilot"),
 		AIModel:           getEnv("AI_MODEL", "claude-sonnet-4"),
+		AIEnabled:         boolEnv(getBoolEnv("AI_ENABLED", true)),
+		AIHealthPath:      getEnv("AI_HEALTH_PATH", "/global/health"),
+		AIWait:            durationEnv(getDurationEnv("AI_WAIT", 0)),
+		AIPollInterval:    durationEnv(getDurationEnv("AI_POLL_INTERVAL", 500*time.Millisecond)),
+		AIPersona:         getEnv("AI_PERSONA", ""),
+		AISystemPrompt:    getEnv("AI_SYSTEM_PROMPT", ""),
+		AIIncludeContext:  boolEnv(getBoolEnv("AI_INCLUDE_CONTEXT", false)),
+		WeekStart:         getEnv("WEEK_START", "monday"),
+		CompletedInline:   boolEnv(getBoolEnv("COMPLETED_INLINE", false)),
+		LineEndings:       getEnv("LINE_ENDINGS", "lf"),
+		TagsStyle:         getEnv("TAGS_STYLE", "block"),
+		CheckboxCase:      getEnv("CHECKBOX_CASE", "lower"),
+		IconPending:       getEnv("ICON_PENDING", ""),
+		IconDone:          getEnv("ICON_DONE", ""),
+		StaleThreshold:    intEnv(getIntEnv("STALE_THRESHOLD", 0)),
+		ListMaxItems:      intEnv(getIntEnv("LIST_MAX_ITEMS", 0)),
+		GitAutoCommit:     boolEnv(getBoolEnv("GIT_AUTOCOMMIT", false)),
+		Aliases:           getEnv("ALIASES", ""),
+		WorkplaceSubdir:   boolEnv(getBoolEnv("PER_WORKPLACE_SUBDIR", false)),
+		AddPosition:       getEnv("ADD_POSITION", "bottom"),
+		VaultDetect:       boolEnv(getBoolEnv("OBSIDIAN_VAULT_DETECT", false)),
 	}
 
-	// Expand ~ in the path
+	// A blank workplace name would produce notes named "2006-01-02-.md";
+	// one of only commas is treated as blank too, as a leftover list typo
+	cfg.WorkplaceName = strings.TrimSpace(cfg.WorkplaceName)
+	if strings.Trim(cfg.WorkplaceName, ", \t") == "" {
+		cfg.WorkplaceName = "Work"
+	}
+
+	// Expand ~ in path-like values set directly in the environment
 	cfg.WorkNotesLocation = expandPath(cfg.WorkNotesLocation)
 
-	return cfg, nil
+	// A vault root resolves to the vault's daily notes folder when asked to
+	if cfg.VaultDetect {
+		cfg.WorkNotesLocation = resolveVaultFolder(cfg.WorkNotesLocation)
+	}
+
+	return cfg, errors.Join(errs...)
+}
+
+// recorder returns a function that keeps a getter's value and records its
+// error, if any, in errs
+func recorder[T any](errs *[]error) func(T, error) T {
+	return func(value T, err error) T {
+		if err != nil {
+			*errs = append(*errs, err)
+		}
+		return value
+	}
 }
 
-// getConfigPath returns the path to the config file
-func getConfigPath() string {
+// Path returns the path to the config file, ~/.config/worklog/config
+func Path() string {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return ""
@@ -71,6 +161,14 @@ func loadConfigFile(path string) {
 		key := strings.TrimSpace(parts[0])
 		value := strings.TrimSpace(parts[1])
 
+		// Expand $VAR and ${VAR} references, then ~ for path-like keys
+		if expandKeys[key] {
+			value = os.ExpandEnv(value)
+		}
+		if pathKeys[key] {
+			value = expandPath(value)
+		}
+
 		// Only set if not already set in environment
 		if _, exists := os.LookupEnv(key); !exists {
 			os.Setenv(key, value)
@@ -86,16 +184,84 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getBoolEnv retrieves a boolean environment variable or returns a default
+// value, which is also returned with an error when the value is not a boolean
+func getBoolEnv(key string, defaultValue bool) (bool, error) {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "1", "true", "yes", "on":
+		return true, nil
+	case "0", "false", "no", "off":
+		return false, nil
+	default:
+		return defaultValue, fmt.Errorf("invalid %s %q (expected true or false)", key, value)
+	}
+}
+
+// getIntEnv retrieves a non-negative integer environment variable or returns
+// a default value, which is also returned with an error for an invalid value
+func getIntEnv(key string, defaultValue int) (int, error) {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return defaultValue, fmt.Errorf("invalid %s %q (expected a whole number, 0 or more)", key, value)
+	}
+	return n, nil
+}
+
+// getDurationEnv retrieves a positive duration environment variable (e.g.
+// "90s") or returns a default value, which is also returned with an error for
+// an invalid value
+func getDurationEnv(key string, defaultValue time.Duration) (time.Duration, error) {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue, nil
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || d <= 0 {
+		return defaultValue, fmt.Errorf("invalid %s %q (expected a positive duration such as 90s)", key, value)
+	}
+	return d, nil
+}
+
 // expandPath expands ~ to the user's home directory
 func expandPath(path string) string {
-	if strings.HasPrefix(path, "~/") {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return path
-		}
-		return filepath.Join(home, path[2:])
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path[1:], "/"))
+}
+
+// resolveVaultFolder returns the daily notes folder configured in an Obsidian
+// vault when dir is a vault root (it contains .obsidian), or dir unchanged
+func resolveVaultFolder(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, ".obsidian", "daily-notes.json"))
+	if err != nil {
+		return dir
+	}
+
+	var settings struct {
+		Folder string `json:"folder"`
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return dir
+	}
+
+	folder := strings.Trim(strings.TrimSpace(settings.Folder), "/")
+	if folder == "" {
+		return dir
 	}
-	return path
+	return filepath.Join(dir, filepath.FromSlash(folder))
 }
 
 // EnsureNotesDirectory creates the notes directory if it doesn't exist
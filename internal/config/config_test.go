@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadReportsInvalidValues(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("AI_WAIT", "5")
+	t.Setenv("AI_POLL_INTERVAL", "-1s")
+	t.Setenv("STALE_THRESHOLD", "three")
+	t.Setenv("GIT_AUTOCOMMIT", "maybe")
+
+	cfg, err := Load()
+	if err == nil {
+		t.Fatal("Load accepted invalid values")
+	}
+	for _, key := range []string{"AI_WAIT", "AI_POLL_INTERVAL", "STALE_THRESHOLD", "GIT_AUTOCOMMIT"} {
+		if !strings.Contains(err.Error(), "invalid "+key) {
+			t.Errorf("error %q does not report %s", err, key)
+		}
+	}
+
+	// Invalid values fall back to their defaults
+	if cfg.AIWait != 0 || cfg.AIPollInterval != 500*time.Millisecond || cfg.StaleThreshold != 0 || cfg.GitAutoCommit {
+		t.Errorf("invalid values not replaced by defaults: %+v", cfg)
+	}
+}
+
+func TestLoadBlankWorkplace(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	for _, value := range []string{"", "   ", ",", ",,,", " , ,\t"} {
+		t.Setenv("WORKPLACE_NAME", value)
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if cfg.WorkplaceName != "Work" {
+			t.Errorf("WORKPLACE_NAME=%q loaded as %q, want %q", value, cfg.WorkplaceName, "Work")
+		}
+	}
+}
+
+func TestLoadExpandsOnlyPathsAndServer(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("NOTES_ROOT", "/srv/notes")
+	t.Setenv("NOTES_HOST", "10.0.0.2")
+	t.Setenv("USD", "dollars")
+	for _, key := range []string{"WORK_NOTES_LOCATION", "OPENCODE_SERVER", "AI_PERSONA"} {
+		// Unset, so the config file provides the values
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+
+	path := filepath.Join(home, ".config", "worklog", "config")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "WORK_NOTES_LOCATION=$NOTES_ROOT/work\nOPENCODE_SERVER=http://${NOTES_HOST}:4096\nAI_PERSONA=Report costs in $USD\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.WorkNotesLocation != "/srv/notes/work" {
+		t.Errorf("WorkNotesLocation = %q, want %q", cfg.WorkNotesLocation, "/srv/notes/work")
+	}
+	if cfg.OpenCodeServer != "http://10.0.0.2:4096" {
+		t.Errorf("OpenCodeServer = %q, want %q", cfg.OpenCodeServer, "http://10.0.0.2:4096")
+	}
+	if cfg.AIPersona != "Report costs in $USD" {
+		t.Errorf("AIPersona = %q, want the $ kept", cfg.AIPersona)
+	}
+}
@@ -0,0 +1,17 @@
+package notes
+
+import "errors"
+
+// Sentinel errors returned by the notes package. Callers should check for
+// them with errors.Is, since they are usually wrapped with the file path.
+var (
+	// ErrNoteNotFound is returned when a note file does not exist
+	ErrNoteNotFound = errors.New("note not found")
+
+	// ErrParseFailed is returned when a note file could not be read to the end
+	ErrParseFailed = errors.New("failed to parse note")
+
+	// ErrNoteLocked is returned when a note stays locked by another program,
+	// such as a sync client, after several write attempts
+	ErrNoteLocked = errors.New("note is locked by another program; close any app syncing it and try again")
+)
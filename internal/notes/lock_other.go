@@ -0,0 +1,14 @@
+//go:build !windows
+
+package notes
+
+import (
+	"errors"
+	"syscall"
+)
+
+// isLockError reports whether err means another program holds a lock on the
+// note. Unix has no sharing modes, so only a busy or locked file counts.
+func isLockError(err error) bool {
+	return errors.Is(err, syscall.EBUSY) || errors.Is(err, syscall.EAGAIN)
+}
@@ -0,0 +1,20 @@
+//go:build windows
+
+package notes
+
+import (
+	"errors"
+	"syscall"
+)
+
+// Windows error codes for a file another process holds open or locked
+const (
+	errorSharingViolation syscall.Errno = 32
+	errorLockViolation    syscall.Errno = 33
+)
+
+// isLockError reports whether err means another program, such as a sync
+// client, has the note open without sharing it or holds a lock on it
+func isLockError(err error) bool {
+	return errors.Is(err, errorSharingViolation) || errors.Is(err, errorLockViolation)
+}
@@ -1,13 +1,151 @@
 package notes
 
 import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 )
 
+// PinMarker is the prefix used in note files to mark a work item as pinned
+const PinMarker = "📌 "
+
+// Checkbox prefixes for work item lines. The writer emits these and the
+// parser reads them, so Obsidian renders completed items as checked.
+const (
+	PendingCheckbox        = "- [ ] "
+	CompletedCheckbox      = "- [x] "
+	UpperCompletedCheckbox = "- [X] "
+)
+
 // WorkItem represents a single work item (pending or completed)
 type WorkItem struct {
 	Text      string
 	Completed bool
+	Pinned    bool
+	Estimate  time.Duration
+	CreatedAt time.Time
+	// CompletedAt is the day the item was done, from a trailing ✅ date as
+	// written by the Obsidian Tasks plugin. Zero when the note has none.
+	CompletedAt time.Time
+	Group       string
+	// UpperMarker records that a completed item was read as - [X] rather
+	// than - [x], so the writer can preserve it
+	UpperMarker bool
+	// Position orders the item within an inline task list (COMPLETED_INLINE),
+	// where pending and completed items share one list. Zero means unplaced.
+	Position int
+}
+
+// ItemGroup is a run of work items sharing the same project heading
+type ItemGroup struct {
+	Name  string
+	Items []WorkItem
+}
+
+// GroupItems groups work items by their project heading. Ungrouped items come
+// first, followed by each group in the order it first appears.
+func GroupItems(items []WorkItem) []ItemGroup {
+	groups := []ItemGroup{{Name: ""}}
+	index := map[string]int{"": 0}
+	for _, item := range items {
+		i, ok := index[item.Group]
+		if !ok {
+			i = len(groups)
+			index[item.Group] = i
+			groups = append(groups, ItemGroup{Name: item.Group})
+		}
+		groups[i].Items = append(groups[i].Items, item)
+	}
+	if len(groups[0].Items) == 0 {
+		groups = groups[1:]
+	}
+	return groups
+}
+
+// CreatedMarker precedes the date an item was added, as in the Obsidian Tasks plugin
+const CreatedMarker = "➕"
+
+// createdRegex matches a trailing created date such as " ➕ 2024-01-15"
+var createdRegex = regexp.MustCompile(`\s+` + CreatedMarker + `\s*(\d{4}-\d{2}-\d{2})$`)
+
+// DoneMarker precedes the date an item was completed, as in the Obsidian Tasks plugin
+const DoneMarker = "✅"
+
+// doneRegex matches a trailing done date such as " ✅ 2024-01-16"
+var doneRegex = regexp.MustCompile(`\s+` + DoneMarker + `\s*(\d{4}-\d{2}-\d{2})$`)
+
+// splitCreated separates a trailing ➕ YYYY-MM-DD created date from item text
+func splitCreated(text string) (string, time.Time) {
+	return splitDate(text, createdRegex)
+}
+
+// splitDone separates a trailing ✅ YYYY-MM-DD done date from item text
+func splitDone(text string) (string, time.Time) {
+	return splitDate(text, doneRegex)
+}
+
+// splitDate separates a trailing marked date matched by re from item text
+func splitDate(text string, re *regexp.Regexp) (string, time.Time) {
+	match := re.FindStringSubmatchIndex(text)
+	if match == nil {
+		return text, time.Time{}
+	}
+	created, err := time.Parse("2006-01-02", text[match[2]:match[3]])
+	if err != nil {
+		return text, time.Time{}
+	}
+	return text[:match[0]], created
+}
+
+// estimateRegex matches a trailing time estimate such as " ~2h" or " ~1h30m"
+var estimateRegex = regexp.MustCompile(`\s+~([0-9]+[hm](?:[0-9]+m)?)$`)
+
+// splitEstimate separates a trailing ~<duration> estimate from item text
+func splitEstimate(text string) (string, time.Duration) {
+	match := estimateRegex.FindStringSubmatchIndex(text)
+	if match == nil {
+		return text, 0
+	}
+	estimate, err := time.ParseDuration(text[match[2]:match[3]])
+	if err != nil || estimate <= 0 {
+		return text, 0
+	}
+	return text[:match[0]], estimate
+}
+
+// FormatEstimate formats an estimate compactly, e.g. 2h, 45m or 1h30m
+func FormatEstimate(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+}
+
+// inlineTagRegex matches Obsidian-style inline tags such as #project or #area/backend
+var inlineTagRegex = regexp.MustCompile(`(?:^|\s)#([\p{L}\p{N}_/-]+)`)
+
+// Tags returns the inline #tags found in the work item text, lowercased and without the #
+func (w WorkItem) Tags() []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, match := range inlineTagRegex.FindAllStringSubmatch(w.Text, -1) {
+		tag := strings.ToLower(match[1])
+		// Obsidian requires at least one non-numeric character in a tag
+		if strings.Trim(tag, "0123456789") == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
 }
 
 // Note represents a daily work note
@@ -18,6 +156,9 @@ type Note struct {
 	Tags    []string
 	Date    time.Time
 
+	// Finalized marks a closed day whose note should no longer be changed
+	Finalized bool
+
 	// Content fields
 	Title            string
 	Summary          string
@@ -26,7 +167,8 @@ type Note struct {
 	CompletedWork    []WorkItem
 
 	// File info
-	FilePath string
+	FilePath  string
+	Workplace string
 }
 
 // NewNote creates a new note for the given date and workplace
@@ -41,6 +183,7 @@ func NewNote(date time.Time, workplaceName string) *Note {
 		YesterdaySummary: "",
 		PendingWork:      []WorkItem{},
 		CompletedWork:    []WorkItem{},
+		Workplace:        workplaceName,
 	}
 }
 
@@ -68,6 +211,53 @@ func GenerateFilename(date time.Time, workplaceName string) string {
 	return date.Format("2006-01-02") + "-" + workplaceName + ".md"
 }
 
+// SubdirFilename creates the filename for a note kept in its workplace's own
+// folder, where the folder names the workplace: YYYY-MM-DD.md
+func SubdirFilename(date time.Time) string {
+	return date.Format("2006-01-02") + ".md"
+}
+
+// EmbedLink returns an Obsidian embed link for a note, e.g. ![[2025-01-19-Work]],
+// or ![[Work/2025-01-19]] when notes are kept in per-workplace folders
+func EmbedLink(date time.Time, workplaceName string, subdir bool) string {
+	if subdir {
+		return "![[" + workplaceName + "/" + strings.TrimSuffix(SubdirFilename(date), ".md") + "]]"
+	}
+	return "![[" + strings.TrimSuffix(GenerateFilename(date, workplaceName), ".md") + "]]"
+}
+
+// Equal reports whether two notes have the same frontmatter, title, summaries
+// and work items. The file path and workplace are not compared, and nil and
+// empty slices are treated alike.
+func (n *Note) Equal(other *Note) bool {
+	if n == nil || other == nil {
+		return n == other
+	}
+	return n.ID == other.ID &&
+		slices.Equal(n.Aliases, other.Aliases) &&
+		slices.Equal(n.Tags, other.Tags) &&
+		n.Date.Equal(other.Date) &&
+		n.Finalized == other.Finalized &&
+		n.Title == other.Title &&
+		n.Summary == other.Summary &&
+		n.YesterdaySummary == other.YesterdaySummary &&
+		slices.EqualFunc(n.PendingWork, other.PendingWork, WorkItem.Equal) &&
+		slices.EqualFunc(n.CompletedWork, other.CompletedWork, WorkItem.Equal)
+}
+
+// Equal reports whether two work items have the same content and markers.
+// Position is not compared: it is assigned while parsing, not stored.
+func (w WorkItem) Equal(other WorkItem) bool {
+	return w.Text == other.Text &&
+		w.Completed == other.Completed &&
+		w.Pinned == other.Pinned &&
+		w.Estimate == other.Estimate &&
+		w.CreatedAt.Equal(other.CreatedAt) &&
+		w.CompletedAt.Equal(other.CompletedAt) &&
+		w.Group == other.Group &&
+		w.UpperMarker == other.UpperMarker
+}
+
 // HasPendingWork returns true if the note has any pending work items
 func (n *Note) HasPendingWork() bool {
 	return len(n.PendingWork) > 0
@@ -78,17 +268,118 @@ func (n *Note) HasCompletedWork() bool {
 	return len(n.CompletedWork) > 0
 }
 
-// AddPendingItem adds a new pending work item
+// AddPendingItem adds a new pending work item stamped with today's date,
+// extracting any trailing ~estimate
 func (n *Note) AddPendingItem(text string) {
-	n.PendingWork = append(n.PendingWork, WorkItem{Text: text, Completed: false})
+	item := newPendingItem(text)
+	item.Position = n.nextPosition()
+	n.PendingWork = append(n.PendingWork, item)
+}
+
+// PrependPendingItem adds a new pending work item, like AddPendingItem, but
+// at the top of the pending list and of an inline task list
+func (n *Note) PrependPendingItem(text string) {
+	for _, items := range [][]WorkItem{n.PendingWork, n.CompletedWork} {
+		for i := range items {
+			if items[i].Position > 0 {
+				items[i].Position++
+			}
+		}
+	}
+	item := newPendingItem(text)
+	item.Position = 1
+	n.PendingWork = append([]WorkItem{item}, n.PendingWork...)
+}
+
+// newPendingItem builds a pending item stamped with today's date, extracting
+// any trailing ~estimate
+func newPendingItem(text string) WorkItem {
+	text, estimate := splitEstimate(text)
+	created, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+	return WorkItem{Text: text, Completed: false, Estimate: estimate, CreatedAt: created}
+}
+
+// PendingEstimate returns the total estimate of pending items and how many have no estimate
+func (n *Note) PendingEstimate() (time.Duration, int) {
+	var total time.Duration
+	unestimated := 0
+	for _, item := range n.PendingWork {
+		if item.Estimate == 0 {
+			unestimated++
+		}
+		total += item.Estimate
+	}
+	return total, unestimated
 }
 
 // AddCompletedItem adds a new completed work item
 func (n *Note) AddCompletedItem(text string) {
-	n.CompletedWork = append(n.CompletedWork, WorkItem{Text: text, Completed: true})
+	n.CompletedWork = append(n.CompletedWork, WorkItem{Text: text, Completed: true, Position: n.nextPosition()})
+}
+
+// HasCompletedItem reports whether the note already has a completed item with
+// the same text, ignoring case and differences in whitespace
+func (n *Note) HasCompletedItem(text string) bool {
+	key := NormalizeItemText(text)
+	for _, item := range n.CompletedWork {
+		if NormalizeItemText(item.Text) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeItemText lowercases text and collapses runs of whitespace, for
+// comparing items that differ only in formatting
+func NormalizeItemText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+// AppendPendingItem adds an existing work item, such as one carried over from
+// another note, to the end of the pending list
+func (n *Note) AppendPendingItem(item WorkItem) {
+	item.Completed = false
+	item.CompletedAt = time.Time{}
+	item.Position = n.nextPosition()
+	n.PendingWork = append(n.PendingWork, item)
+}
+
+// nextPosition returns a position after every item currently in the note
+func (n *Note) nextPosition() int {
+	last := 0
+	for _, items := range [][]WorkItem{n.PendingWork, n.CompletedWork} {
+		for _, item := range items {
+			last = max(last, item.Position)
+		}
+	}
+	return last + 1
+}
+
+// RemovePendingItem removes a pending item by index
+func (n *Note) RemovePendingItem(index int) {
+	if index >= 0 && index < len(n.PendingWork) {
+		n.PendingWork = append(n.PendingWork[:index], n.PendingWork[index+1:]...)
+	}
+}
+
+// RemoveCompletedItem removes a completed item by index
+func (n *Note) RemoveCompletedItem(index int) {
+	if index >= 0 && index < len(n.CompletedWork) {
+		n.CompletedWork = append(n.CompletedWork[:index], n.CompletedWork[index+1:]...)
+	}
+}
+
+// TogglePinned flips the pinned state of a pending item and returns the new state
+func (n *Note) TogglePinned(index int) bool {
+	if index < 0 || index >= len(n.PendingWork) {
+		return false
+	}
+	n.PendingWork[index].Pinned = !n.PendingWork[index].Pinned
+	return n.PendingWork[index].Pinned
 }
 
-// MarkItemCompleted moves a pending item to completed
+// MarkItemCompleted moves a pending item to completed. The item keeps its
+// Position, so in an inline task list it is checked off in place.
 func (n *Note) MarkItemCompleted(index int) {
 	if index >= 0 && index < len(n.PendingWork) {
 		item := n.PendingWork[index]
@@ -0,0 +1,62 @@
+package notes
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNoteEqual(t *testing.T) {
+	date := time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)
+	base := func() *Note {
+		note := NewNote(date, "Work")
+		note.FilePath = "/notes/2025-01-17-Work.md"
+		note.PendingWork = []WorkItem{{Text: "Write docs", Position: 0}, {Text: "Review PR", Position: 1}}
+		return note
+	}
+
+	t.Run("identical", func(t *testing.T) {
+		if !base().Equal(base()) {
+			t.Error("identical notes are not equal")
+		}
+	})
+
+	t.Run("nil and empty slices", func(t *testing.T) {
+		a, b := base(), base()
+		a.Aliases, a.CompletedWork = nil, nil
+		b.Aliases, b.CompletedWork = []string{}, []WorkItem{}
+		if !a.Equal(b) {
+			t.Error("nil and empty slices are not equal")
+		}
+	})
+
+	t.Run("reordered items", func(t *testing.T) {
+		a, b := base(), base()
+		b.PendingWork[0], b.PendingWork[1] = b.PendingWork[1], b.PendingWork[0]
+		if a.Equal(b) {
+			t.Error("notes with reordered items are equal")
+		}
+	})
+
+	t.Run("different positions", func(t *testing.T) {
+		a, b := base(), base()
+		b.PendingWork[0].Position, b.PendingWork[1].Position = 3, 5
+		if !a.Equal(b) {
+			t.Error("notes differing only in item positions are not equal")
+		}
+	})
+
+	t.Run("different file path", func(t *testing.T) {
+		a, b := base(), base()
+		b.FilePath = "/notes/Work/2025-01-17.md"
+		if !a.Equal(b) {
+			t.Error("notes differing only in file path are not equal")
+		}
+	})
+
+	t.Run("nil notes", func(t *testing.T) {
+		var a *Note
+		if !a.Equal(nil) || a.Equal(base()) || base().Equal(nil) {
+			t.Error("nil notes compare wrongly")
+		}
+	})
+}
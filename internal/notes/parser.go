@@ -2,6 +2,8 @@ package notes
 
 import (
 	"bufio"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -14,6 +16,8 @@ import (
 type Parser struct {
 	notesDir      string
 	workplaceName string
+	subdir        bool
+	warn          func(message string)
 }
 
 // NewParser creates a new note parser
@@ -24,29 +28,86 @@ func NewParser(notesDir, workplaceName string) *Parser {
 	}
 }
 
-// ParseFile reads and parses a markdown note file
+// SetWorkplaceSubdir makes the parser look for notes in the workplace's own
+// folder, <notesDir>/<workplace>/YYYY-MM-DD.md
+func (p *Parser) SetWorkplaceSubdir(subdir bool) {
+	p.subdir = subdir
+}
+
+// dir returns the directory holding this workplace's notes
+func (p *Parser) dir() string {
+	if p.subdir {
+		return filepath.Join(p.notesDir, p.workplaceName)
+	}
+	return p.notesDir
+}
+
+// filename returns the canonical filename of the note for date
+func (p *Parser) filename(date time.Time) string {
+	if p.subdir {
+		return SubdirFilename(date)
+	}
+	return GenerateFilename(date, p.workplaceName)
+}
+
+// SetWarningHandler sets a function called with non-fatal problems found
+// while locating notes, such as duplicate files for the same date
+func (p *Parser) SetWarningHandler(warn func(message string)) {
+	p.warn = warn
+}
+
+// warnf reports a non-fatal problem through the warning handler, if set
+func (p *Parser) warnf(format string, args ...any) {
+	if p.warn != nil {
+		p.warn(fmt.Sprintf(format, args...))
+	}
+}
+
+// ParseFile reads and parses a markdown note file. If the file cannot be read
+// to the end it returns a nil note and an error wrapping ErrParseFailed, so a
+// partially read note is never written back over the original.
 func (p *Parser) ParseFile(filePath string) (*Note, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNoteNotFound, filePath)
+		}
 		return nil, err
 	}
 	defer file.Close()
 
+	return p.parse(file, filePath)
+}
+
+// parse reads a note from r; filePath names it and its workplace
+func (p *Parser) parse(r io.Reader, filePath string) (*Note, error) {
+	// The filename names the workplace a note belongs to; fall back to the
+	// configured one for files not following the naming scheme
+	workplace := workplaceFromPath(filePath)
+	if workplace == "" {
+		workplace = p.workplaceName
+	}
+
 	note := &Note{
 		FilePath:      filePath,
+		Workplace:     workplace,
 		Aliases:       []string{},
 		Tags:          []string{},
 		PendingWork:   []WorkItem{},
 		CompletedWork: []WorkItem{},
 	}
 
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
 	inFrontmatter := false
 	inPendingSection := false
 	inCompletedSection := false
+	inTasksSection := false
+	group := ""
+	position := 0
 
 	for scanner.Scan() {
-		line := scanner.Text()
+		// Tolerate CRLF line endings from notes edited on Windows
+		line := strings.TrimSuffix(scanner.Text(), "\r")
 
 		// Handle frontmatter
 		if line == "---" {
@@ -85,30 +146,57 @@ func (p *Parser) ParseFile(filePath string) (*Note, error) {
 		if strings.HasPrefix(line, "## Pending Work") {
 			inPendingSection = true
 			inCompletedSection = false
+			inTasksSection = false
+			group = ""
 			continue
 		}
 
 		if strings.HasPrefix(line, "## Work Completed") {
 			inPendingSection = false
 			inCompletedSection = true
+			inTasksSection = false
+			group = ""
 			continue
 		}
 
-		// Handle work items
-		if inPendingSection {
-			if item := p.parseWorkItem(line); item != nil {
-				note.PendingWork = append(note.PendingWork, *item)
-			}
+		// A combined task list (COMPLETED_INLINE) sorts items by their checkbox
+		if strings.HasPrefix(line, TasksHeading) {
+			inPendingSection = false
+			inCompletedSection = false
+			inTasksSection = true
+			group = ""
+			continue
 		}
 
-		if inCompletedSection {
-			if item := p.parseWorkItem(line); item != nil {
-				note.CompletedWork = append(note.CompletedWork, *item)
-			}
+		// Handle project headings within a section
+		if strings.HasPrefix(line, "### ") {
+			group = strings.TrimSpace(strings.TrimPrefix(line, "### "))
+			continue
+		}
+
+		// Handle work items
+		if !inPendingSection && !inCompletedSection && !inTasksSection {
+			continue
+		}
+		item := p.parseWorkItem(line)
+		if item == nil {
+			continue
+		}
+		position++
+		item.Group = group
+		item.Position = position
+		if inCompletedSection || (inTasksSection && item.Completed) {
+			note.CompletedWork = append(note.CompletedWork, *item)
+		} else {
+			note.PendingWork = append(note.PendingWork, *item)
 		}
 	}
 
-	return note, scanner.Err()
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrParseFailed, filePath, err)
+	}
+
+	return note, nil
 }
 
 // parseFrontmatterLine parses a single frontmatter line
@@ -120,6 +208,19 @@ func (p *Parser) parseFrontmatterLine(line string, note *Note) {
 		if t, err := time.Parse("2006-01-02", dateStr); err == nil {
 			note.Date = t
 		}
+	} else if strings.HasPrefix(line, "finalized:") {
+		// Accept the inline field form finalized:: true as well
+		value := strings.TrimPrefix(strings.TrimPrefix(line, "finalized:"), ":")
+		note.Finalized = strings.EqualFold(strings.TrimSpace(value), "true")
+	} else if strings.HasPrefix(line, "tags:") {
+		// Inline forms: tags: [work, job] or tags: work, job
+		value := strings.TrimSpace(strings.TrimPrefix(line, "tags:"))
+		value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.Trim(strings.TrimSpace(tag), `"'`); tag != "" {
+				note.Tags = append(note.Tags, tag)
+			}
+		}
 	} else if strings.HasPrefix(line, "  - ") {
 		// This is a tag or alias item
 		tag := strings.TrimSpace(strings.TrimPrefix(line, "  - "))
@@ -131,90 +232,376 @@ func (p *Parser) parseFrontmatterLine(line string, note *Note) {
 func (p *Parser) parseWorkItem(line string) *WorkItem {
 	line = strings.TrimSpace(line)
 
+	var item *WorkItem
+
 	// Match unchecked: - [ ] task
-	if strings.HasPrefix(line, "- [ ] ") {
-		return &WorkItem{
-			Text:      strings.TrimPrefix(line, "- [ ] "),
+	if strings.HasPrefix(line, PendingCheckbox) {
+		item = &WorkItem{
+			Text:      strings.TrimPrefix(line, PendingCheckbox),
 			Completed: false,
 		}
 	}
 
 	// Match checked: - [x] task
-	if strings.HasPrefix(line, "- [x] ") || strings.HasPrefix(line, "- [X] ") {
-		text := strings.TrimPrefix(line, "- [x] ")
-		text = strings.TrimPrefix(text, "- [X] ")
-		return &WorkItem{
-			Text:      text,
+	if strings.HasPrefix(line, CompletedCheckbox) {
+		item = &WorkItem{
+			Text:      strings.TrimPrefix(line, CompletedCheckbox),
 			Completed: true,
 		}
 	}
 
-	return nil
+	// Match checked with a capital marker: - [X] task
+	if strings.HasPrefix(line, UpperCompletedCheckbox) {
+		item = &WorkItem{
+			Text:        strings.TrimPrefix(line, UpperCompletedCheckbox),
+			Completed:   true,
+			UpperMarker: true,
+		}
+	}
+
+	if item == nil {
+		return nil
+	}
+
+	// Match pinned: - [ ] 📌 task
+	if strings.HasPrefix(item.Text, PinMarker) {
+		item.Text = strings.TrimPrefix(item.Text, PinMarker)
+		item.Pinned = true
+	}
+
+	// Match done date, created date and estimate: - [x] task ~2h ➕ 2024-01-15 ✅ 2024-01-16
+	item.Text, item.CompletedAt = splitDone(item.Text)
+	item.Text, item.CreatedAt = splitCreated(item.Text)
+	item.Text, item.Estimate = splitEstimate(item.Text)
+
+	return item
 }
 
-// FindMostRecentNote finds the most recent note before the given date
-func (p *Parser) FindMostRecentNote(beforeDate time.Time) (*Note, error) {
-	pattern := filepath.Join(p.notesDir, "*.md")
-	files, err := filepath.Glob(pattern)
-	if err != nil {
-		return nil, err
+// DroppedLines returns the lines of original whose content is missing from
+// rendered, ignoring blank lines, whitespace, line order and the formatting
+// the writer normalizes, such as tag style and checkbox case. It finds what
+// rewriting a hand-edited note would lose: free text outside the task
+// sections, unknown frontmatter fields or entries of lists other than tags.
+func DroppedLines(original, rendered string) []string {
+	kept := make(map[string]int)
+	for _, line := range contentLines(rendered) {
+		kept[line.key]++
 	}
 
-	if len(files) == 0 {
-		return nil, nil
+	var dropped []string
+	for _, line := range contentLines(original) {
+		if kept[line.key] > 0 {
+			kept[line.key]--
+			continue
+		}
+		dropped = append(dropped, line.text)
 	}
+	return dropped
+}
+
+// contentLine is a line of a note and a key identifying its content
+type contentLine struct {
+	text string
+	key  string
+}
+
+// contentLines keys each line of a note by its content, so equivalent lines
+// written differently share a key. Blank lines and section headings, which
+// carry no content of their own, are left out.
+func contentLines(content string) []contentLine {
+	var lines []contentLine
+	inFrontmatter := false
+	listKey := ""
+
+	for _, raw := range strings.Split(content, "\n") {
+		text := strings.TrimSpace(raw)
+		if text == "---" {
+			inFrontmatter = !inFrontmatter
+			continue
+		}
+		if text == "" {
+			continue
+		}
+
+		if inFrontmatter {
+			// A list entry belongs to the key above it
+			if entry, ok := strings.CutPrefix(text, "- "); ok {
+				lines = append(lines, contentLine{text, listKey + ": " + strings.TrimSpace(entry)})
+				continue
+			}
+			key, value, _ := strings.Cut(text, ":")
+			key = strings.TrimSpace(key)
+			value = strings.TrimSpace(strings.TrimPrefix(value, ":"))
+			listKey = key
+
+			switch {
+			case key == "finalized" && !strings.EqualFold(value, "true"):
+				// Only finalized: true is written
+			case strings.HasPrefix(value, "[") || (key == "tags" && value != ""):
+				// An inline list is keyed like the entries of a block list
+				for _, entry := range strings.Split(strings.Trim(value, "[]"), ",") {
+					if entry = strings.Trim(strings.TrimSpace(entry), `"'`); entry != "" {
+						lines = append(lines, contentLine{text, key + ": " + entry})
+					}
+				}
+			case value != "":
+				lines = append(lines, contentLine{text, key + ": " + value})
+			}
+			continue
+		}
 
-	// Parse dates from filenames and sort
-	type fileDate struct {
-		path string
-		date time.Time
+		if text == "## Pending Work" || text == "## Work Completed" || text == TasksHeading {
+			continue
+		}
+		if strings.HasPrefix(text, "summary::") || strings.HasPrefix(text, "yesterday's summary::") {
+			field, value, _ := strings.Cut(text, "::")
+			if value = strings.TrimSpace(value); value != "" {
+				lines = append(lines, contentLine{text, field + ":: " + value})
+			}
+			continue
+		}
+		if item := (&Parser{}).parseWorkItem(text); item != nil {
+			key := PendingCheckbox
+			if item.Completed {
+				key = CompletedCheckbox
+			}
+			lines = append(lines, contentLine{text, key + strings.Join(strings.Fields(formatItemText(*item)), " ")})
+			continue
+		}
+		lines = append(lines, contentLine{text, strings.Join(strings.Fields(text), " ")})
 	}
+	return lines
+}
 
-	var validFiles []fileDate
-	dateRegex := regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})-.*\.md$`)
+// NoteFile describes a note file on disk and the date parsed from its filename
+type NoteFile struct {
+	Path string
+	Date time.Time
+}
 
+// Workplace returns the workplace name from the note's path: the part of the
+// filename after the date, or the folder name for a YYYY-MM-DD.md note kept
+// in a per-workplace folder
+func (nf NoteFile) Workplace() string {
+	return workplaceFromPath(nf.Path)
+}
+
+// workplaceFromPath returns the workplace a note path names, or "" if the
+// filename is in neither the YYYY-MM-DD-Workplace.md nor the YYYY-MM-DD.md format
+func workplaceFromPath(path string) string {
+	basename := filepath.Base(path)
+	switch {
+	case noteFilenameRegex.MatchString(basename):
+		return strings.TrimSuffix(basename[len("2006-01-02-"):], ".md")
+	case subdirFilenameRegex.MatchString(basename):
+		return filepath.Base(filepath.Dir(path))
+	}
+	return ""
+}
+
+// noteFilenameRegex matches note filenames in the format YYYY-MM-DD-*.md
+var noteFilenameRegex = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})-.*\.md$`)
+
+// subdirFilenameRegex matches note filenames in a per-workplace folder, YYYY-MM-DD.md
+var subdirFilenameRegex = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})\.md$`)
+
+// ListNoteFiles returns all dated note files in the notes directory, sorted by date ascending
+func (p *Parser) ListNoteFiles() ([]NoteFile, error) {
+	filenameRegex := noteFilenameRegex
+	if p.subdir {
+		filenameRegex = subdirFilenameRegex
+	}
+
+	pattern := filepath.Join(p.dir(), "*.md")
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var noteFiles []NoteFile
 	for _, f := range files {
 		basename := filepath.Base(f)
-		matches := dateRegex.FindStringSubmatch(basename)
+		matches := filenameRegex.FindStringSubmatch(basename)
 		if len(matches) >= 2 {
 			if date, err := time.Parse("2006-01-02", matches[1]); err == nil {
-				// Only include dates before the target date
-				if date.Before(beforeDate) {
-					validFiles = append(validFiles, fileDate{path: f, date: date})
-				}
+				noteFiles = append(noteFiles, NoteFile{Path: f, Date: date})
 			}
 		}
 	}
 
-	if len(validFiles) == 0 {
-		return nil, nil
+	sort.SliceStable(noteFiles, func(i, j int) bool {
+		return noteFiles[i].Date.Before(noteFiles[j].Date)
+	})
+
+	return p.dedupeNoteFiles(noteFiles), nil
+}
+
+// ListAllNoteFiles returns the dated note files of every workplace, sorted by
+// date ascending. With per-workplace folders it looks in each folder of the
+// notes directory; otherwise it is the same as ListNoteFiles.
+func (p *Parser) ListAllNoteFiles() ([]NoteFile, error) {
+	if !p.subdir {
+		return p.ListNoteFiles()
+	}
+
+	entries, err := os.ReadDir(p.notesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var noteFiles []NoteFile
+	for _, entry := range entries {
+		// Skip hidden folders such as .obsidian and .git
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		workplace := &Parser{notesDir: p.notesDir, workplaceName: entry.Name(), subdir: true, warn: p.warn}
+		files, err := workplace.ListNoteFiles()
+		if err != nil {
+			return nil, err
+		}
+		noteFiles = append(noteFiles, files...)
 	}
 
-	// Sort by date descending (most recent first)
-	sort.Slice(validFiles, func(i, j int) bool {
-		return validFiles[i].date.After(validFiles[j].date)
+	sort.SliceStable(noteFiles, func(i, j int) bool {
+		return noteFiles[i].Date.Before(noteFiles[j].Date)
 	})
+	return noteFiles, nil
+}
+
+// dedupeNoteFiles collapses files whose names differ only in case (e.g.
+// 2024-01-01-Work.md and 2024-01-01-work.md) into a single entry
+func (p *Parser) dedupeNoteFiles(noteFiles []NoteFile) []NoteFile {
+	groups := make(map[string][]string)
+	for _, nf := range noteFiles {
+		key := strings.ToLower(filepath.Base(nf.Path))
+		groups[key] = append(groups[key], nf.Path)
+	}
+
+	var result []NoteFile
+	seen := make(map[string]bool)
+	for _, nf := range noteFiles {
+		key := strings.ToLower(filepath.Base(nf.Path))
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		nf.Path = p.pickCanonical(p.filename(nf.Date), groups[key])
+		result = append(result, nf)
+	}
 
-	// Return the most recent note
-	return p.ParseFile(validFiles[0].path)
+	return result
 }
 
-// FindTodayNote finds today's note if it exists
-func (p *Parser) FindTodayNote(date time.Time) (*Note, error) {
-	filename := GenerateFilename(date, p.workplaceName)
-	filePath := filepath.Join(p.notesDir, filename)
+// pickCanonical chooses deterministically between files that match the same
+// note name case-insensitively, preferring the exact canonical filename and
+// otherwise the first in sorted order. It warns when there is more than one.
+func (p *Parser) pickCanonical(canonical string, candidates []string) string {
+	if len(candidates) == 0 {
+		return filepath.Join(p.dir(), canonical)
+	}
 
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return nil, nil
+	sorted := append([]string(nil), candidates...)
+	sort.Strings(sorted)
+
+	chosen := sorted[0]
+	for _, candidate := range sorted {
+		if filepath.Base(candidate) == canonical {
+			chosen = candidate
+			break
+		}
 	}
 
-	return p.ParseFile(filePath)
+	if len(sorted) > 1 {
+		var names []string
+		for _, candidate := range sorted {
+			names = append(names, filepath.Base(candidate))
+		}
+		p.warnf("Found duplicate notes %s; using %s", strings.Join(names, ", "), filepath.Base(chosen))
+	}
+
+	return chosen
+}
+
+// FindMostRecentNote finds the most recent note before the given date
+func (p *Parser) FindMostRecentNote(beforeDate time.Time) (*Note, error) {
+	recent, err := p.FindRecentNotes(beforeDate, 1)
+	if err != nil || len(recent) == 0 {
+		return nil, err
+	}
+	return recent[0], nil
+}
+
+// FindRecentNotes finds up to limit notes before the given date, most recent first
+func (p *Parser) FindRecentNotes(beforeDate time.Time, limit int) ([]*Note, error) {
+	noteFiles, err := p.ListNoteFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Note
+	for i := len(noteFiles) - 1; i >= 0 && len(result) < limit; i-- {
+		// Only include dates before the target date
+		if !noteFiles[i].Date.Before(beforeDate) {
+			continue
+		}
+		note, err := p.ParseFile(noteFiles[i].Path)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, note)
+	}
+
+	return result, nil
+}
+
+// FindNotesInRange finds all notes dated from `from` up to but not including `to`, oldest first
+func (p *Parser) FindNotesInRange(from, to time.Time) ([]*Note, error) {
+	noteFiles, err := p.ListNoteFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Note
+	for _, nf := range noteFiles {
+		if nf.Date.Before(from) || !nf.Date.Before(to) {
+			continue
+		}
+		note, err := p.ParseFile(nf.Path)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, note)
+	}
+
+	return result, nil
+}
+
+// NotePath returns the file path of the note for the given date. An existing
+// file whose name matches case-insensitively is preferred over the canonical
+// name, so notes renamed by other tools are still found.
+func (p *Parser) NotePath(date time.Time) string {
+	canonical := p.filename(date)
+	matches, _ := filepath.Glob(filepath.Join(p.dir(), date.Format("2006-01-02")+"*.md"))
+
+	var candidates []string
+	for _, match := range matches {
+		if strings.EqualFold(filepath.Base(match), canonical) {
+			candidates = append(candidates, match)
+		}
+	}
+
+	return p.pickCanonical(canonical, candidates)
+}
+
+// FindTodayNote finds the note for the given date, returning ErrNoteNotFound if it doesn't exist
+func (p *Parser) FindTodayNote(date time.Time) (*Note, error) {
+	return p.ParseFile(p.NotePath(date))
 }
 
 // NoteExists checks if a note exists for the given date
 func (p *Parser) NoteExists(date time.Time) bool {
-	filename := GenerateFilename(date, p.workplaceName)
-	filePath := filepath.Join(p.notesDir, filename)
-	_, err := os.Stat(filePath)
+	_, err := os.Stat(p.NotePath(date))
 	return err == nil
 }
@@ -0,0 +1,220 @@
+package notes
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDroppedLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		rendered string
+		want     []string
+	}{
+		{
+			name:     "whitespace, order and formatting",
+			original: "---\ntags: [work, job]\nfinalized: false\n---\n# Day\n\n- [X]  done ~90m\n- [ ] first\nsummary::\n",
+			rendered: "---\ntags:\n  - job\n  - work\n---\n\n# Day\n\n- [ ] first\n\n- [x] done ~1h30m\n",
+		},
+		{
+			name:     "free text",
+			original: "# Day\n\nA paragraph\n- [ ] first\n",
+			rendered: "# Day\n\n- [ ] first\n",
+			want:     []string{"A paragraph"},
+		},
+		{
+			name:     "unknown frontmatter field",
+			original: "---\nid: day\nmood: fine\n---\n",
+			rendered: "---\nid: day\n---\n",
+			want:     []string{"mood: fine"},
+		},
+		{
+			name:     "aliases folded into tags",
+			original: "---\naliases:\n  - standup\ntags:\n  - work\n---\n",
+			rendered: "---\naliases: []\ntags:\n  - work\n  - standup\n---\n",
+			want:     []string{"- standup"},
+		},
+		{
+			name:     "duplicate line",
+			original: "# Day\n\n- [ ] same\n- [ ] same\n",
+			rendered: "# Day\n\n- [ ] same\n",
+			want:     []string{"- [ ] same"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DroppedLines(tt.original, tt.rendered); !slices.Equal(got, tt.want) {
+				t.Errorf("DroppedLines = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListAllNoteFilesSubdir(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"Work/2025-01-01.md", "Side/2025-01-02.md", "Work/2025-01-03.md", ".obsidian/2025-01-04.md"} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	parser := NewParser(dir, "Work")
+	parser.SetWorkplaceSubdir(true)
+	files, err := parser.ListAllNoteFiles()
+	if err != nil {
+		t.Fatalf("ListAllNoteFiles: %v", err)
+	}
+
+	var got []string
+	for _, file := range files {
+		got = append(got, file.Workplace()+" "+file.Date.Format("2006-01-02"))
+	}
+	want := []string{"Work 2025-01-01", "Side 2025-01-02", "Work 2025-01-03"}
+	if !slices.Equal(got, want) {
+		t.Errorf("ListAllNoteFiles = %q, want %q", got, want)
+	}
+}
+
+// canonicalNote is a note in exactly the format the writer produces
+const canonicalNote = `---
+id: Work-17-Jan-2025
+aliases: []
+tags:
+  - work
+  - job
+date: 2025-01-17
+finalized: true
+---
+
+# 2025-01-17
+
+summary:: Shipped the login fix.
+
+yesterday's summary:: Reviewed the design doc.
+
+## Pending Work
+
+- [ ] 📌 Write release notes ~1h30m ➕ 2025-01-15
+- [ ] Follow up on #auth tickets
+
+### Billing
+
+- [ ] Fix invoice rounding ~2h
+
+## Work Completed
+
+- [x] Fix login bug ➕ 2025-01-16
+
+### Billing
+
+- [x] Review tax rules
+
+`
+
+// writeNoteFile writes content to a note file in a temporary directory and
+// returns its path
+func writeNoteFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "2025-01-17-Work.md")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseRenderRoundTrip(t *testing.T) {
+	path := writeNoteFile(t, canonicalNote)
+	note, err := NewParser(filepath.Dir(path), "Work").ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+
+	var rendered bytes.Buffer
+	if err := NewWriter(filepath.Dir(path), "Work").Render(&rendered, note); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if rendered.String() != canonicalNote {
+		t.Errorf("round trip changed the note:\n%s\nwant:\n%s", rendered.String(), canonicalNote)
+	}
+}
+
+func TestParseFileCRLF(t *testing.T) {
+	lf := writeNoteFile(t, canonicalNote)
+	crlf := writeNoteFile(t, strings.ReplaceAll(canonicalNote, "\n", "\r\n"))
+
+	parser := NewParser(filepath.Dir(lf), "Work")
+	want, err := parser.ParseFile(lf)
+	if err != nil {
+		t.Fatalf("ParseFile(LF): %v", err)
+	}
+	got, err := parser.ParseFile(crlf)
+	if err != nil {
+		t.Fatalf("ParseFile(CRLF): %v", err)
+	}
+
+	if !got.Equal(want) {
+		t.Errorf("CRLF note parsed as %+v, want %+v", got, want)
+	}
+	if got.Title != "2025-01-17" || len(got.PendingWork) != 3 || len(got.CompletedWork) != 2 {
+		t.Errorf("CRLF note parsed as title %q with %d pending and %d completed, want 2025-01-17, 3 and 2",
+			got.Title, len(got.PendingWork), len(got.CompletedWork))
+	}
+}
+
+// failingReader returns its data, then err instead of EOF
+type failingReader struct {
+	data string
+	err  error
+}
+
+func (r *failingReader) Read(b []byte) (int, error) {
+	if r.data == "" {
+		return 0, r.err
+	}
+	n := copy(b, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestParseReadError(t *testing.T) {
+	readErr := errors.New("input/output error")
+	r := &failingReader{data: canonicalNote[:len(canonicalNote)/2], err: readErr}
+
+	note, err := NewParser(t.TempDir(), "Work").parse(r, "2025-01-17-Work.md")
+	if note != nil {
+		t.Errorf("parse returned a partial note: %+v", note)
+	}
+	if !errors.Is(err, ErrParseFailed) || !errors.Is(err, readErr) {
+		t.Errorf("parse error = %v, want ErrParseFailed wrapping the read error", err)
+	}
+}
+
+func TestParseDoneDate(t *testing.T) {
+	path := writeNoteFile(t, "## Work Completed\n\n- [x] Ship it ~1h ➕ 2025-01-15 ✅ 2025-01-17\n")
+
+	note, err := NewParser(filepath.Dir(path), "Work").ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	if len(note.CompletedWork) != 1 {
+		t.Fatalf("completed items = %+v, want one", note.CompletedWork)
+	}
+	item := note.CompletedWork[0]
+	if item.Text != "Ship it" || item.CompletedAt.Format("2006-01-02") != "2025-01-17" || item.CreatedAt.Format("2006-01-02") != "2025-01-15" {
+		t.Errorf("parsed item = %+v", item)
+	}
+	if got := formatItemText(item); got != "Ship it ~1h ➕ 2025-01-15 ✅ 2025-01-17" {
+		t.Errorf("formatItemText = %q, want the markers written back", got)
+	}
+}
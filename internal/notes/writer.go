@@ -1,17 +1,47 @@
 package notes
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 )
 
+// writeAttempts and writeRetryDelay bound the retries when a note is locked
+const (
+	writeAttempts   = 5
+	writeRetryDelay = 200 * time.Millisecond
+)
+
+// TasksHeading is the heading of the single combined task list written when
+// completed items are kept inline
+const TasksHeading = "## Tasks"
+
+// CheckboxCase controls the marker written for completed items
+type CheckboxCase int
+
+const (
+	// CheckboxLower writes completed items as - [x]
+	CheckboxLower CheckboxCase = iota
+	// CheckboxUpper writes completed items as - [X]
+	CheckboxUpper
+	// CheckboxPreserve keeps the marker each item was read with; new items use - [x]
+	CheckboxPreserve
+)
+
 // Writer handles writing markdown notes to disk
 type Writer struct {
-	notesDir      string
-	workplaceName string
+	notesDir        string
+	workplaceName   string
+	completedInline bool
+	crlf            bool
+	inlineTags      bool
+	checkboxCase    CheckboxCase
+	subdir          bool
 }
 
 // NewWriter creates a new note writer
@@ -22,21 +52,149 @@ func NewWriter(notesDir, workplaceName string) *Writer {
 	}
 }
 
-// WriteNote writes a note to disk
-func (w *Writer) WriteNote(note *Note) error {
+// SetCompletedInline makes the writer emit one combined task list, with
+// completed items checked off in place, instead of separate pending and
+// completed sections
+func (w *Writer) SetCompletedInline(inline bool) {
+	w.completedInline = inline
+}
+
+// SetCRLF makes the writer use Windows (CRLF) line endings instead of LF
+func (w *Writer) SetCRLF(crlf bool) {
+	w.crlf = crlf
+}
+
+// SetCheckboxCase sets the marker written for completed items
+func (w *Writer) SetCheckboxCase(c CheckboxCase) {
+	w.checkboxCase = c
+}
+
+// SetInlineTags makes the writer emit frontmatter tags as an inline list,
+// tags: [a, b], instead of a YAML block list
+func (w *Writer) SetInlineTags(inline bool) {
+	w.inlineTags = inline
+}
+
+// SetWorkplaceSubdir makes the writer keep notes in a folder per workplace,
+// <notesDir>/<workplace>/YYYY-MM-DD.md, instead of <notesDir>/YYYY-MM-DD-<workplace>.md
+func (w *Writer) SetWorkplaceSubdir(subdir bool) {
+	w.subdir = subdir
+}
+
+// notePath returns where the note for date is written
+func (w *Writer) notePath(date time.Time) string {
+	if w.subdir {
+		return filepath.Join(w.notesDir, w.workplaceName, SubdirFilename(date))
+	}
+	return filepath.Join(w.notesDir, GenerateFilename(date, w.workplaceName))
+}
+
+// withLineEndings converts generated LF content to the configured line endings
+func (w *Writer) withLineEndings(content string) string {
+	if w.crlf {
+		return strings.ReplaceAll(content, "\n", "\r\n")
+	}
+	return content
+}
+
+// WriteNote writes a note to disk and returns its absolute path. A file that
+// already holds exactly the rendered note is left untouched, so its
+// modification time and sync state do not change; written reports whether
+// the file was written.
+func (w *Writer) WriteNote(note *Note) (path string, written bool, err error) {
 	if note.FilePath == "" {
-		note.FilePath = filepath.Join(w.notesDir, GenerateFilename(note.Date, w.workplaceName))
+		note.FilePath = w.notePath(note.Date)
+	}
+	if w.subdir {
+		if err := os.MkdirAll(filepath.Dir(note.FilePath), 0755); err != nil {
+			return "", false, err
+		}
+	}
+
+	content := []byte(w.withLineEndings(w.generateMarkdown(note)))
+	existing, err := os.ReadFile(note.FilePath)
+	written = err != nil || !bytes.Equal(existing, content)
+	if written {
+		if err := writeFileWithRetry(note.FilePath, content); err != nil {
+			return "", false, err
+		}
+	}
+
+	path, err = filepath.Abs(note.FilePath)
+	if err != nil {
+		return note.FilePath, written, nil
 	}
+	return path, written, nil
+}
 
-	content := w.generateMarkdown(note)
-	return os.WriteFile(note.FilePath, []byte(content), 0644)
+// writeFileWithRetry writes a file atomically, retrying for a short while when
+// it is locked, as happens while a sync client holds it open. Other errors,
+// including a plain permission denied, are returned straight away.
+func writeFileWithRetry(path string, data []byte) error {
+	var err error
+	for attempt := 0; attempt < writeAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(writeRetryDelay)
+		}
+		if err = writeFileAtomic(path, data); err == nil || !isLockError(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("%w: %s: %w", ErrNoteLocked, path, err)
 }
 
-// CreateTodayNote creates a new note for today
-func (w *Writer) CreateTodayNote(date time.Time) *Note {
+// writeFileAtomic writes data to a temporary file beside path and renames it
+// into place, so a failed write never leaves a partial note. A symlinked note
+// is written through to its target, and an existing note keeps its mode.
+func writeFileAtomic(path string, data []byte) error {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	// Flush to disk before the rename, so a crash can't leave an empty note
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Render writes the markdown content of a note to an arbitrary io.Writer
+func (w *Writer) Render(out io.Writer, note *Note) error {
+	_, err := io.WriteString(out, w.withLineEndings(w.generateMarkdown(note)))
+	return err
+}
+
+// CreateTodayNote creates a new note for the given date and immediately writes
+// the empty template to disk, so the file exists before any further changes.
+// Callers mutate the returned note and persist those changes with WriteNote.
+func (w *Writer) CreateTodayNote(date time.Time) (*Note, error) {
 	note := NewNote(date, w.workplaceName)
-	note.FilePath = filepath.Join(w.notesDir, GenerateFilename(date, w.workplaceName))
-	return note
+	note.FilePath = w.notePath(date)
+	if _, _, err := w.WriteNote(note); err != nil {
+		return nil, err
+	}
+	return note, nil
 }
 
 // generateMarkdown generates the markdown content for a note
@@ -47,11 +205,18 @@ func (w *Writer) generateMarkdown(note *Note) string {
 	sb.WriteString("---\n")
 	sb.WriteString(fmt.Sprintf("id: %s\n", note.ID))
 	sb.WriteString("aliases: []\n")
-	sb.WriteString("tags:\n")
-	for _, tag := range note.Tags {
-		sb.WriteString(fmt.Sprintf("  - %s\n", tag))
+	if w.inlineTags {
+		sb.WriteString(fmt.Sprintf("tags: [%s]\n", strings.Join(note.Tags, ", ")))
+	} else {
+		sb.WriteString("tags:\n")
+		for _, tag := range note.Tags {
+			sb.WriteString(fmt.Sprintf("  - %s\n", tag))
+		}
 	}
 	sb.WriteString(fmt.Sprintf("date: %s\n", note.Date.Format("2006-01-02")))
+	if note.Finalized {
+		sb.WriteString("finalized: true\n")
+	}
 	sb.WriteString("---\n\n")
 
 	// Title
@@ -61,23 +226,114 @@ func (w *Writer) generateMarkdown(note *Note) string {
 	sb.WriteString(fmt.Sprintf("summary::%s\n\n", formatInlineSummary(note.Summary)))
 	sb.WriteString(fmt.Sprintf("yesterday's summary::%s\n\n", formatInlineSummary(note.YesterdaySummary)))
 
+	// Task sections
+	sb.WriteString(w.generateTaskSections(note))
+
+	return sb.String()
+}
+
+// generateTaskSections generates the pending and completed work sections of a note
+func (w *Writer) generateTaskSections(note *Note) string {
+	var sb strings.Builder
+
+	if w.completedInline {
+		sb.WriteString(TasksHeading + "\n\n")
+		w.writeGroupedItems(&sb, inlineItems(note), "")
+		return sb.String()
+	}
+
 	// Pending Work section
 	sb.WriteString("## Pending Work\n\n")
-	for _, item := range note.PendingWork {
-		sb.WriteString(fmt.Sprintf("- [ ] %s\n", item.Text))
-	}
-	sb.WriteString("\n")
+	w.writeGroupedItems(&sb, note.PendingWork, PendingCheckbox)
 
 	// Work Completed section
 	sb.WriteString("## Work Completed\n\n")
-	for _, item := range note.CompletedWork {
-		sb.WriteString(fmt.Sprintf("- [x] %s\n", item.Text))
-	}
-	sb.WriteString("\n")
+	w.writeGroupedItems(&sb, note.CompletedWork, CompletedCheckbox)
 
 	return sb.String()
 }
 
+// inlineItems merges pending and completed items into one list ordered by
+// Position. Unplaced items keep their relative order after the placed ones.
+func inlineItems(note *Note) []WorkItem {
+	items := append(append([]WorkItem{}, note.PendingWork...), note.CompletedWork...)
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i].Position, items[j].Position
+		if a == 0 || b == 0 {
+			return a != 0 && b == 0
+		}
+		return a < b
+	})
+	return items
+}
+
+// writeGroupedItems writes checkbox items, emitting a ### heading for each
+// project group. An empty prefix picks each item's checkbox from its status.
+func (w *Writer) writeGroupedItems(sb *strings.Builder, items []WorkItem, prefix string) {
+	if len(items) == 0 {
+		sb.WriteString("\n")
+		return
+	}
+	for _, group := range GroupItems(items) {
+		if group.Name != "" {
+			sb.WriteString(fmt.Sprintf("### %s\n\n", group.Name))
+		}
+		for _, item := range group.Items {
+			checkbox := prefix
+			if checkbox == "" {
+				checkbox = PendingCheckbox
+				if item.Completed {
+					checkbox = CompletedCheckbox
+				}
+			}
+			if checkbox == CompletedCheckbox {
+				checkbox = w.completedCheckbox(item)
+			}
+			sb.WriteString(checkbox + formatItemText(item) + "\n")
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// completedCheckbox returns the marker for a completed item under the
+// configured checkbox case
+func (w *Writer) completedCheckbox(item WorkItem) string {
+	switch {
+	case w.checkboxCase == CheckboxUpper:
+		return UpperCompletedCheckbox
+	case w.checkboxCase == CheckboxPreserve && item.UpperMarker:
+		return UpperCompletedCheckbox
+	default:
+		return CompletedCheckbox
+	}
+}
+
+// RenderTaskSections writes only the task sections of a note (no frontmatter,
+// title or summaries), suitable for embedding in another note
+func (w *Writer) RenderTaskSections(out io.Writer, note *Note) error {
+	_, err := io.WriteString(out, w.withLineEndings(w.generateTaskSections(note)))
+	return err
+}
+
+// formatItemText formats a work item's text with any markers, e.g. the pin prefix,
+// the trailing estimate and the created and done dates
+func formatItemText(item WorkItem) string {
+	text := item.Text
+	if item.Pinned {
+		text = PinMarker + text
+	}
+	if item.Estimate > 0 {
+		text += " ~" + FormatEstimate(item.Estimate)
+	}
+	if !item.CreatedAt.IsZero() {
+		text += " " + CreatedMarker + " " + item.CreatedAt.Format("2006-01-02")
+	}
+	if !item.CompletedAt.IsZero() {
+		text += " " + DoneMarker + " " + item.CompletedAt.Format("2006-01-02")
+	}
+	return text
+}
+
 // formatInlineSummary formats the summary for inline display
 func formatInlineSummary(summary string) string {
 	if summary == "" {
@@ -89,29 +345,34 @@ func formatInlineSummary(summary string) string {
 // UpdateSummary updates the summary field in an existing note
 func (w *Writer) UpdateSummary(note *Note, summary string) error {
 	note.Summary = summary
-	return w.WriteNote(note)
+	_, _, err := w.WriteNote(note)
+	return err
 }
 
 // UpdateYesterdaySummary updates the yesterday's summary field
 func (w *Writer) UpdateYesterdaySummary(note *Note, summary string) error {
 	note.YesterdaySummary = summary
-	return w.WriteNote(note)
+	_, _, err := w.WriteNote(note)
+	return err
 }
 
 // MovePendingToCompleted moves all pending items to completed for an item
 func (w *Writer) MovePendingToCompleted(note *Note, index int) error {
 	note.MarkItemCompleted(index)
-	return w.WriteNote(note)
+	_, _, err := w.WriteNote(note)
+	return err
 }
 
 // AddPendingItem adds a pending item to a note and saves
 func (w *Writer) AddPendingItem(note *Note, text string) error {
 	note.AddPendingItem(text)
-	return w.WriteNote(note)
+	_, _, err := w.WriteNote(note)
+	return err
 }
 
 // AddCompletedItem adds a completed item to a note and saves
 func (w *Writer) AddCompletedItem(note *Note, text string) error {
 	note.AddCompletedItem(text)
-	return w.WriteNote(note)
+	_, _, err := w.WriteNote(note)
+	return err
 }
@@ -0,0 +1,116 @@
+package notes
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteFileAtomicKeepsModeAndSymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.md")
+	link := filepath.Join(dir, "link.md")
+	if err := os.WriteFile(target, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	if err := writeFileAtomic(link, []byte("new")); err != nil {
+		t.Fatalf("writeFileAtomic: %v", err)
+	}
+
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("link replaced by a regular file (err %v)", err)
+	}
+	data, err := os.ReadFile(target)
+	if err != nil || string(data) != "new" {
+		t.Fatalf("target = %q, %v; want %q", data, err, "new")
+	}
+	if info, _ := os.Stat(target); info.Mode().Perm() != 0600 {
+		t.Errorf("target mode = %v, want %v", info.Mode().Perm(), os.FileMode(0600))
+	}
+}
+
+func TestWriteFileAtomicNewFileMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "note.md")
+	if err := writeFileAtomic(path, []byte("new")); err != nil {
+		t.Fatalf("writeFileAtomic: %v", err)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0644 {
+		t.Errorf("mode = %v, want %v", info.Mode().Perm(), os.FileMode(0644))
+	}
+}
+
+func TestCompletedOrderKept(t *testing.T) {
+	dir := t.TempDir()
+	date := time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)
+	note := NewNote(date, "Work")
+	for _, text := range []string{"third", "first", "second"} {
+		note.AddCompletedItem(text)
+	}
+
+	writer := NewWriter(dir, "Work")
+	path, _, err := writer.WriteNote(note)
+	if err != nil {
+		t.Fatalf("WriteNote: %v", err)
+	}
+	parsed, err := NewParser(dir, "Work").ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+
+	var got []string
+	for _, item := range parsed.CompletedWork {
+		got = append(got, item.Text)
+	}
+	if want := []string{"third", "first", "second"}; !slices.Equal(got, want) {
+		t.Errorf("completed items = %q, want %q", got, want)
+	}
+}
+
+func TestTagsStyle(t *testing.T) {
+	note := NewNote(time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC), "Work")
+
+	tests := []struct {
+		name   string
+		inline bool
+		want   string
+	}{
+		{name: "block", want: "aliases: []\ntags:\n  - work\n  - job\ndate: 2025-01-17\n"},
+		{name: "inline", inline: true, want: "aliases: []\ntags: [work, job]\ndate: 2025-01-17\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := NewWriter(t.TempDir(), "Work")
+			writer.SetInlineTags(tt.inline)
+
+			var rendered bytes.Buffer
+			if err := writer.Render(&rendered, note); err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			if !strings.Contains(rendered.String(), tt.want) {
+				t.Errorf("frontmatter missing %q:\n%s", tt.want, rendered.String())
+			}
+
+			// Either style reads back as the same tags
+			path := filepath.Join(t.TempDir(), "2025-01-17-Work.md")
+			if err := os.WriteFile(path, rendered.Bytes(), 0644); err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := NewParser(filepath.Dir(path), "Work").ParseFile(path)
+			if err != nil {
+				t.Fatalf("ParseFile: %v", err)
+			}
+			if !slices.Equal(parsed.Tags, note.Tags) {
+				t.Errorf("tags read back as %q, want %q", parsed.Tags, note.Tags)
+			}
+		})
+	}
+}
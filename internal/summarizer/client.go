@@ -7,31 +7,219 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/sandepten/work-obsidian-noter/internal/notes"
 )
 
+// maxPollInterval caps the backoff between polls for a response
+const maxPollInterval = 5 * time.Second
+
+// connectionCacheTTL is how long a successful connection test is trusted
+const connectionCacheTTL = 60 * time.Second
+
+// dialTimeout bounds connecting to the server, for requests and the event stream alike
+const dialTimeout = 10 * time.Second
+
+// personaPresets are the named AI_PERSONA values; any other value is used as
+// a free-form instruction
+var personaPresets = map[string]string{
+	"engineer": "Write as a concise software engineer: precise and technical, naming the systems and changes involved.",
+	"manager":  "Write for a manager: focus on outcomes, impact and progress rather than implementation details.",
+	"casual":   "Write in a relaxed, friendly tone, as if updating a teammate in chat.",
+}
+
 // Client handles communication with the OpenCode server for AI summaries
 type Client struct {
 	baseURL    string
 	providerID string
-	modelID    string
-	httpClient *http.Client
+	// modelIDs are tried in order until one produces a summary
+	modelIDs   []string
+	healthPath string
+	// eventWait bounds sending the message and the wait for the SSE idle
+	// event, and pollWait bounds the polling fallback when no response
+	// arrived by then
+	eventWait time.Duration
+	pollWait  time.Duration
+	// pollInterval is the initial delay between polls; it backs off up to maxPollInterval
+	pollInterval time.Duration
+	// connectionCache is a file recording the last successful connection test
+	connectionCache string
+	// persona is an instruction on tone and voice placed before each prompt
+	persona string
+	// systemPrompt, when set, is sent as the session's system prompt and
+	// replaces the built-in instructions in each message
+	systemPrompt string
+	logf         func(format string, args ...any)
+	httpClient   *http.Client
+	// streamClient reads the SSE event stream and sends messages, whose
+	// response only arrives once the reply is generated. It has no overall
+	// timeout, leaving each request bounded by its context, but shares
+	// httpClient's transport and so its dial timeout and pooled connections.
+	streamClient *http.Client
+	// lastModel is the provider/model that produced the latest summary
+	lastModel string
 }
 
-// NewClient creates a new OpenCode API client
-func NewClient(baseURL, providerID, modelID string) *Client {
+// NewClient creates a new OpenCode API client.
+// A base URL without a scheme (e.g. 127.0.0.1:4096) is treated as http.
+// modelID may list several comma-separated models to fall back through in order.
+func NewClient(baseURL, providerID, modelID string) (*Client, error) {
+	normalized, err := normalizeBaseURL(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var modelIDs []string
+	for _, id := range strings.Split(modelID, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			modelIDs = append(modelIDs, id)
+		}
+	}
+	if len(modelIDs) == 0 {
+		return nil, fmt.Errorf("no AI model configured")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: dialTimeout, KeepAlive: 30 * time.Second}).DialContext
+
 	return &Client{
-		baseURL:    strings.TrimSuffix(baseURL, "/"),
-		providerID: providerID,
-		modelID:    modelID,
+		baseURL:      normalized,
+		providerID:   providerID,
+		modelIDs:     modelIDs,
+		healthPath:   "/global/health",
+		eventWait:    60 * time.Second,
+		pollWait:     30 * time.Second,
+		pollInterval: 500 * time.Millisecond,
 		httpClient: &http.Client{
-			Timeout: 120 * time.Second,
+			Transport: transport,
+			Timeout:   120 * time.Second,
+		},
+		streamClient: &http.Client{
+			Transport: transport,
 		},
+	}, nil
+}
+
+// SetHealthPath sets the endpoint path probed by TestConnection.
+// An empty path disables the health check and the server is assumed reachable.
+func (c *Client) SetHealthPath(path string) {
+	path = strings.TrimSpace(path)
+	if path != "" && !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	c.healthPath = path
+}
+
+// SetWaitTimeout sets how long to wait for a response, both for the reply to
+// be generated and for the polling fallback. Non-positive values are ignored.
+func (c *Client) SetWaitTimeout(wait time.Duration) {
+	if wait <= 0 {
+		return
+	}
+	c.eventWait = wait
+	c.pollWait = wait
+}
+
+// SetPollInterval sets the initial interval between polls while waiting for a
+// response. Non-positive values are ignored.
+func (c *Client) SetPollInterval(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	c.pollInterval = interval
+}
+
+// SetConnectionCache sets a file used to remember a successful connection test
+// across runs, so back-to-back commands skip the probe for a short while
+func (c *Client) SetConnectionCache(path string) {
+	c.connectionCache = path
+}
+
+// SetPersona sets the voice summaries are written in, either a preset name
+// (engineer, manager, casual) or free-form instructions. Empty clears it.
+func (c *Client) SetPersona(persona string) {
+	persona = strings.TrimSpace(persona)
+	if preset, ok := personaPresets[strings.ToLower(persona)]; ok {
+		persona = preset
+	}
+	c.persona = persona
+}
+
+// withPersona places the persona instruction ahead of a prompt, if one is set.
+// With a system prompt the persona is sent there instead.
+func (c *Client) withPersona(prompt string) string {
+	if c.persona == "" || c.systemPrompt != "" {
+		return prompt
+	}
+	return c.persona + "\n\n" + prompt
+}
+
+// SetSystemPrompt sets a system prompt sent with every message. When set, the
+// messages carry only the work to summarize, without built-in instructions.
+func (c *Client) SetSystemPrompt(prompt string) {
+	c.systemPrompt = strings.TrimSpace(prompt)
+}
+
+// SystemPrompt returns the system prompt sent with each message, including
+// any persona, or "" when none is configured
+func (c *Client) SystemPrompt() string {
+	if c.systemPrompt == "" || c.persona == "" {
+		return c.systemPrompt
+	}
+	return c.systemPrompt + "\n\n" + c.persona
+}
+
+// brevity is the length instruction for prompts. The default also asks for a
+// professional tone, which is left out when a persona sets the tone instead.
+func (c *Client) brevity() string {
+	if c.persona == "" {
+		return "Keep it brief and professional."
+	}
+	return "Keep it brief."
+}
+
+// SetLogger sets a function that receives verbose diagnostics such as phase timings
+func (c *Client) SetLogger(logf func(format string, args ...any)) {
+	c.logf = logf
+}
+
+// debugf writes verbose diagnostics through the logger, if set
+func (c *Client) debugf(format string, args ...any) {
+	if c.logf != nil {
+		c.logf(format, args...)
+	}
+}
+
+// normalizeBaseURL prepends http:// to a URL missing a scheme and validates the result
+func normalizeBaseURL(baseURL string) (string, error) {
+	raw := strings.TrimSpace(baseURL)
+	if raw == "" {
+		return "", fmt.Errorf("OpenCode server URL is empty")
+	}
+
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid OpenCode server URL %q: %w", baseURL, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("invalid OpenCode server URL %q: scheme must be http or https", baseURL)
 	}
+	if parsed.Host == "" {
+		return "", fmt.Errorf("invalid OpenCode server URL %q: missing host", baseURL)
+	}
+
+	return strings.TrimSuffix(raw, "/"), nil
 }
 
 // Session represents an OpenCode session
@@ -47,8 +235,9 @@ type TextPart struct {
 
 // PromptRequest represents the request body for sending a message
 type PromptRequest struct {
-	Model *ModelSpec `json:"model,omitempty"`
-	Parts []TextPart `json:"parts"`
+	Model  *ModelSpec `json:"model,omitempty"`
+	System string     `json:"system,omitempty"`
+	Parts  []TextPart `json:"parts"`
 }
 
 // ModelSpec specifies which model to use
@@ -65,8 +254,17 @@ type MessageResponse struct {
 
 // MessageInfo contains message metadata
 type MessageInfo struct {
-	ID   string `json:"id"`
-	Role string `json:"role"`
+	ID    string        `json:"id"`
+	Role  string        `json:"role"`
+	Error *MessageError `json:"error,omitempty"`
+}
+
+// MessageError describes why an assistant message failed, e.g. an unknown model
+type MessageError struct {
+	Name string `json:"name"`
+	Data struct {
+		Message string `json:"message"`
+	} `json:"data"`
 }
 
 // Part represents a message part in the response
@@ -82,8 +280,8 @@ type SSEEvent struct {
 }
 
 // createSession creates a new session for summarization
-func (c *Client) createSession() (*Session, error) {
-	req, err := http.NewRequest("POST", c.baseURL+"/session", bytes.NewBuffer([]byte("{}")))
+func (c *Client) createSession(ctx context.Context) (*Session, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/session", bytes.NewBuffer([]byte("{}")))
 	if err != nil {
 		return nil, err
 	}
@@ -108,13 +306,15 @@ func (c *Client) createSession() (*Session, error) {
 	return &session, nil
 }
 
-// sendMessageAsync sends a message to a session (async - returns immediately)
-func (c *Client) sendMessageAsync(sessionID string, prompt string) error {
+// sendMessage sends a message to a session. The server responds once the
+// reply has been generated, so ctx should allow for the whole generation.
+func (c *Client) sendMessage(ctx context.Context, sessionID, prompt, modelID string) error {
 	requestBody := PromptRequest{
 		Model: &ModelSpec{
 			ProviderID: c.providerID,
-			ModelID:    c.modelID,
+			ModelID:    modelID,
 		},
+		System: c.SystemPrompt(),
 		Parts: []TextPart{
 			{Type: "text", Text: prompt},
 		},
@@ -126,13 +326,13 @@ func (c *Client) sendMessageAsync(sessionID string, prompt string) error {
 	}
 
 	url := fmt.Sprintf("%s/session/%s/message", c.baseURL, sessionID)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.streamClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send message: %w", err)
 	}
@@ -147,23 +347,34 @@ func (c *Client) sendMessageAsync(sessionID string, prompt string) error {
 }
 
 // waitForIdleWithPolling polls the messages endpoint until we get an assistant response
-func (c *Client) waitForIdleWithPolling(sessionID string, timeout time.Duration) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+func (c *Client) waitForIdleWithPolling(ctx context.Context, sessionID string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	ticker := time.NewTicker(500 * time.Millisecond)
-	defer ticker.Stop()
+	// Poll quickly at first, then back off gently so slow generations
+	// don't hammer the server
+	interval := c.pollInterval
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return fmt.Errorf("timeout waiting for response")
-		case <-ticker.C:
-			messages, err := c.getMessages(sessionID)
+		case <-timer.C:
+			interval = min(interval*3/2, max(maxPollInterval, c.pollInterval))
+			timer.Reset(interval)
+
+			messages, err := c.getMessages(ctx, sessionID)
 			if err != nil {
 				continue
 			}
 
+			// A failed assistant message will never produce content
+			if assistantError(messages) != nil {
+				return nil
+			}
+
 			// Check if we have an assistant message with content
 			for _, msg := range messages {
 				if msg.Info.Role == "assistant" {
@@ -179,26 +390,40 @@ func (c *Client) waitForIdleWithPolling(sessionID string, timeout time.Duration)
 	}
 }
 
-// startEventListener starts listening to SSE events and returns a channel for idle notifications
-func (c *Client) startEventListener(ctx context.Context, sessionID string) <-chan struct{} {
+// startEventListener starts listening to SSE events and returns a channel for
+// idle notifications, plus a channel that is closed once the event stream is
+// connected (or has failed to connect) so callers know when it's safe to send
+func (c *Client) startEventListener(ctx context.Context, sessionID string) (<-chan struct{}, <-chan struct{}) {
 	idleChan := make(chan struct{}, 1)
+	ready := make(chan struct{})
 
 	go func() {
 		defer close(idleChan)
 
+		readyClosed := false
+		markReady := func() {
+			if !readyClosed {
+				close(ready)
+				readyClosed = true
+			}
+		}
+		defer markReady()
+
 		req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/event", nil)
 		if err != nil {
 			return
 		}
 		req.Header.Set("Accept", "text/event-stream")
 
-		client := &http.Client{}
-		resp, err := client.Do(req)
+		resp, err := c.streamClient.Do(req)
 		if err != nil {
 			return
 		}
 		defer resp.Body.Close()
 
+		// Response headers received - the stream is live
+		markReady()
+
 		scanner := bufio.NewScanner(resp.Body)
 		for scanner.Scan() {
 			select {
@@ -235,13 +460,13 @@ func (c *Client) startEventListener(ctx context.Context, sessionID string) <-cha
 		}
 	}()
 
-	return idleChan
+	return idleChan, ready
 }
 
 // getMessages retrieves all messages from a session
-func (c *Client) getMessages(sessionID string) ([]MessageResponse, error) {
+func (c *Client) getMessages(ctx context.Context, sessionID string) ([]MessageResponse, error) {
 	url := fmt.Sprintf("%s/session/%s/message", c.baseURL, sessionID)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -265,6 +490,19 @@ func (c *Client) getMessages(sessionID string) ([]MessageResponse, error) {
 	return messages, nil
 }
 
+// assistantError returns the error reported on an assistant message, if any
+func assistantError(messages []MessageResponse) error {
+	for _, msg := range messages {
+		if msg.Info.Role == "assistant" && msg.Info.Error != nil {
+			if msg.Info.Error.Data.Message != "" {
+				return fmt.Errorf("%s: %s", msg.Info.Error.Name, msg.Info.Error.Data.Message)
+			}
+			return fmt.Errorf("%s", msg.Info.Error.Name)
+		}
+	}
+	return nil
+}
+
 // extractAssistantResponse extracts text from assistant messages
 func (c *Client) extractAssistantResponse(messages []MessageResponse) string {
 	var result strings.Builder
@@ -282,93 +520,320 @@ func (c *Client) extractAssistantResponse(messages []MessageResponse) string {
 	return strings.TrimSpace(result.String())
 }
 
-// SummarizeWorkItems generates an AI summary of completed work items
-func (c *Client) SummarizeWorkItems(items []notes.WorkItem) (string, error) {
+// SummarizeContext describes the work being summarized, so the prompt can
+// give the AI the date and number of items
+type SummarizeContext struct {
+	Date      time.Time
+	Completed int
+}
+
+// describe renders the context as a line placed before the item list
+func (sc *SummarizeContext) describe() string {
+	return fmt.Sprintf("On %s, completed %d item(s):", sc.Date.Format("Monday, January 2, 2006"), sc.Completed)
+}
+
+// SummarizeWorkItems generates an AI summary of completed work items. info
+// may be nil to leave the date and counts out of the prompt.
+func (c *Client) SummarizeWorkItems(ctx context.Context, items []notes.WorkItem, info *SummarizeContext) (string, error) {
 	if len(items) == 0 {
 		return "No work items to summarize.", nil
 	}
 
-	// Build the prompt
+	return c.Summarize(ctx, c.WorkItemsPrompt(items, info))
+}
+
+// WorkItemsPrompt builds the prompt SummarizeWorkItems sends for the given items
+func (c *Client) WorkItemsPrompt(items []notes.WorkItem, info *SummarizeContext) string {
 	var sb strings.Builder
-	sb.WriteString("Summarize the following completed work items in 1-2 concise sentences. Focus on the key accomplishments and outcomes. Keep it brief and professional. Do not use any tools, just respond with plain text:\n\n")
+	if c.systemPrompt != "" {
+		sb.WriteString("Completed work items:\n\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("Summarize the following completed work items in 1-2 concise sentences. Focus on the key accomplishments and outcomes. %s Do not use any tools, just respond with plain text:\n\n", c.brevity()))
+	}
 
+	if info != nil {
+		sb.WriteString(info.describe() + "\n")
+	}
 	for _, item := range items {
 		sb.WriteString(fmt.Sprintf("- %s\n", item.Text))
 	}
 
+	return c.withPersona(sb.String())
+}
+
+// SummarizeWeek writes a short overview of several days of work. Each day is
+// described by its stored summary, or by its completed items when it has none.
+func (c *Client) SummarizeWeek(ctx context.Context, days []*notes.Note) (string, error) {
+	var sb strings.Builder
+	if c.systemPrompt != "" {
+		sb.WriteString("Daily work summaries for the week:\n\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("Write a 2-3 sentence overview of the week from the following daily work summaries. Focus on the main themes and outcomes. %s Do not use any tools, just respond with plain text:\n\n", c.brevity()))
+	}
+
+	for _, day := range days {
+		if day.Summary != "" {
+			sb.WriteString(fmt.Sprintf("- %s: %s\n", day.Date.Format("Monday"), day.Summary))
+			continue
+		}
+		var items []string
+		for _, item := range day.CompletedWork {
+			items = append(items, item.Text)
+		}
+		if len(items) > 0 {
+			sb.WriteString(fmt.Sprintf("- %s: completed %s\n", day.Date.Format("Monday"), strings.Join(items, "; ")))
+		}
+	}
+
+	return c.Summarize(ctx, c.withPersona(sb.String()))
+}
+
+// Summarize sends an arbitrary prompt to a new OpenCode session and returns
+// the assistant's text response. SummarizeWorkItems builds on this.
+// Each configured model is tried in order until one succeeds.
+func (c *Client) Summarize(ctx context.Context, prompt string) (string, error) {
+	var lastErr error
+
+	for i, modelID := range c.modelIDs {
+		response, err := c.summarizeWithModel(ctx, prompt, modelID)
+		if err == nil {
+			c.lastModel = c.providerID + "/" + modelID
+			c.debugf("summary generated by %s", c.lastModel)
+			return response, nil
+		}
+
+		// Don't fall through to other models if the caller cancelled
+		if ctx.Err() != nil {
+			return "", err
+		}
+
+		lastErr = err
+		if i < len(c.modelIDs)-1 {
+			c.debugf("model %s/%s failed: %v; trying next model", c.providerID, modelID, err)
+		}
+	}
+
+	// Probe the server again next time rather than trusting a cached success
+	c.invalidateConnectionCache()
+	return "", lastErr
+}
+
+// LastModel returns the provider/model that produced the most recent
+// summary, which may be a fallback model, or "" before any succeeded
+func (c *Client) LastModel() string {
+	return c.lastModel
+}
+
+// summarizeWithModel runs a single prompt through one model in a new session
+func (c *Client) summarizeWithModel(ctx context.Context, prompt, modelID string) (string, error) {
+	start := time.Now()
+
 	// Create session
-	session, err := c.createSession()
+	phaseStart := time.Now()
+	session, err := c.createSession(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to create session: %w", err)
 	}
+	c.debugf("session created in %s", time.Since(phaseStart).Round(time.Millisecond))
 
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	waitCtx, cancel := context.WithTimeout(ctx, c.eventWait)
 	defer cancel()
 
-	// Start event listener BEFORE sending message
-	idleChan := c.startEventListener(ctx, session.ID)
-
-	// Small delay to ensure listener is ready
-	time.Sleep(100 * time.Millisecond)
+	// Start event listener BEFORE sending message and wait until it's connected
+	idleChan, listenerReady := c.startEventListener(waitCtx, session.ID)
+	select {
+	case <-listenerReady:
+	case <-waitCtx.Done():
+	}
 
-	// Send message asynchronously
-	if err := c.sendMessageAsync(session.ID, sb.String()); err != nil {
+	// Send the message, allowing the wait for the reply to be generated. A
+	// send cut off by the wait may still have reached the server, so the
+	// messages are checked below as for any other timeout.
+	phaseStart = time.Now()
+	if err := c.sendMessage(waitCtx, session.ID, prompt, modelID); err != nil && (ctx.Err() != nil || waitCtx.Err() == nil) {
 		return "", fmt.Errorf("failed to send message: %w", err)
 	}
+	c.debugf("reply generated in %s", time.Since(phaseStart).Round(time.Millisecond))
+	phaseStart = time.Now()
 
 	// Wait for either SSE idle event or timeout
 	select {
 	case <-idleChan:
 		// Session is idle
-	case <-ctx.Done():
+	case <-waitCtx.Done():
 		// Timeout - but let's still try to get messages in case we missed the event
 	}
 
+	// Stop here if the caller cancelled rather than the wait timing out
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
 	// Get messages and extract response
-	messages, err := c.getMessages(session.ID)
+	messages, err := c.getMessages(ctx, session.ID)
 	if err != nil {
 		return "", fmt.Errorf("failed to get messages: %w", err)
 	}
 
+	if err := assistantError(messages); err != nil {
+		return "", err
+	}
+
 	response := c.extractAssistantResponse(messages)
 	if response == "" {
 		// If no response via SSE, try polling
-		if err := c.waitForIdleWithPolling(session.ID, 30*time.Second); err != nil {
+		if err := c.waitForIdleWithPolling(ctx, session.ID, c.pollWait); err != nil {
 			return "", fmt.Errorf("no response received from AI: %w", err)
 		}
 
 		// Try getting messages again
-		messages, err = c.getMessages(session.ID)
+		messages, err = c.getMessages(ctx, session.ID)
 		if err != nil {
 			return "", fmt.Errorf("failed to get messages: %w", err)
 		}
 
+		if err := assistantError(messages); err != nil {
+			return "", err
+		}
+
 		response = c.extractAssistantResponse(messages)
 		if response == "" {
 			return "", fmt.Errorf("no response received from AI")
 		}
 	}
 
+	c.debugf("response collected in %s", time.Since(phaseStart).Round(time.Millisecond))
+	c.debugf("summary took %s in total", time.Since(start).Round(time.Millisecond))
+
 	return response, nil
 }
 
-// TestConnection tests if the OpenCode server is reachable
-func (c *Client) TestConnection() error {
-	req, err := http.NewRequest("GET", c.baseURL+"/global/health", nil)
+// TestConnection tests if the OpenCode server is reachable via its health endpoint
+func (c *Client) TestConnection(ctx context.Context) error {
+	if c.healthPath == "" {
+		return nil
+	}
+
+	if c.connectionCached() {
+		c.debugf("connection test skipped (recent success cached)")
+		return nil
+	}
+
+	return c.Probe(ctx)
+}
+
+// Probe checks the health endpoint like TestConnection, but always contacts
+// the server rather than trusting a cached success. The result still updates
+// the cache.
+func (c *Client) Probe(ctx context.Context) error {
+	if c.healthPath == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+c.healthPath, nil)
 	if err != nil {
 		return err
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		c.invalidateConnectionCache()
 		return fmt.Errorf("failed to connect to OpenCode server: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		c.invalidateConnectionCache()
 		return fmt.Errorf("OpenCode server returned status %d", resp.StatusCode)
 	}
 
+	c.cacheConnection()
 	return nil
 }
+
+// connectionCached reports whether a connection test to this server succeeded
+// within connectionCacheTTL
+func (c *Client) connectionCached() bool {
+	if c.connectionCache == "" {
+		return false
+	}
+	info, err := os.Stat(c.connectionCache)
+	if err != nil || time.Since(info.ModTime()) > connectionCacheTTL {
+		return false
+	}
+	data, err := os.ReadFile(c.connectionCache)
+	return err == nil && string(data) == c.baseURL+c.healthPath
+}
+
+// cacheConnection records a successful connection test. Failures to write
+// the cache are ignored; the next run simply probes again.
+func (c *Client) cacheConnection() {
+	if c.connectionCache == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.connectionCache), 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(c.connectionCache, []byte(c.baseURL+c.healthPath), 0644)
+}
+
+// invalidateConnectionCache forgets any cached connection success
+func (c *Client) invalidateConnectionCache() {
+	if c.connectionCache != "" {
+		_ = os.Remove(c.connectionCache)
+	}
+}
+
+// providersResponse is the subset of GET /config/providers used to check models
+type providersResponse struct {
+	Providers []struct {
+		ID     string                     `json:"id"`
+		Models map[string]json.RawMessage `json:"models"`
+	} `json:"providers"`
+}
+
+// Models returns the configured provider and models, in fallback order
+func (c *Client) Models() (string, []string) {
+	return c.providerID, c.modelIDs
+}
+
+// MissingModels asks the server which providers and models it has configured
+// and returns the configured models it does not offer
+func (c *Client) MissingModels(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/config/providers", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list providers: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to list providers: status %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var providers providersResponse
+	if err := json.NewDecoder(resp.Body).Decode(&providers); err != nil {
+		return nil, fmt.Errorf("failed to decode providers: %w", err)
+	}
+
+	for _, provider := range providers.Providers {
+		if provider.ID != c.providerID {
+			continue
+		}
+		var missing []string
+		for _, modelID := range c.modelIDs {
+			if _, ok := provider.Models[modelID]; !ok {
+				missing = append(missing, modelID)
+			}
+		}
+		return missing, nil
+	}
+
+	return nil, fmt.Errorf("provider %q is not configured on the server", c.providerID)
+}
@@ -0,0 +1,295 @@
+package summarizer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeServer is a minimal OpenCode server covering the endpoints Summarize uses
+type fakeServer struct {
+	// idle sends a session.idle event once the message has been received
+	idle bool
+	// generate is how long the message request takes to return, as the
+	// server only responds once the reply has been generated
+	generate time.Duration
+	// reply returns the assistant text for the nth messages request (from
+	// 1), or "" while the response is still being generated
+	reply func(n int) string
+
+	mu       sync.Mutex
+	requests int
+	sent     chan struct{}
+	// subscribed is set once the event stream is open, and subscribedFirst
+	// records whether it was when the message arrived
+	subscribed      bool
+	subscribedFirst bool
+}
+
+func newFakeServer(t *testing.T, f *fakeServer) *Client {
+	t.Helper()
+
+	f.sent = make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /session", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Session{ID: "ses_1"})
+	})
+	mux.HandleFunc("POST /session/ses_1/message", func(w http.ResponseWriter, r *http.Request) {
+		var body PromptRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Parts) == 0 {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		f.mu.Lock()
+		f.subscribedFirst = f.subscribed
+		f.mu.Unlock()
+		select {
+		case <-time.After(f.generate):
+		case <-r.Context().Done():
+		}
+		close(f.sent)
+	})
+	mux.HandleFunc("GET /session/ses_1/message", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.requests++
+		n := f.requests
+		f.mu.Unlock()
+
+		messages := []MessageResponse{{Info: MessageInfo{ID: "msg_1", Role: "user"}}}
+		if text := f.reply(n); text != "" {
+			messages = append(messages, MessageResponse{
+				Info:  MessageInfo{ID: "msg_2", Role: "assistant"},
+				Parts: []Part{{Type: "text", Text: text}},
+			})
+		}
+		json.NewEncoder(w).Encode(messages)
+	})
+	mux.HandleFunc("GET /event", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.subscribed = true
+		f.mu.Unlock()
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.(http.Flusher).Flush()
+
+		select {
+		case <-f.sent:
+		case <-r.Context().Done():
+			return
+		}
+		if f.idle {
+			fmt.Fprintf(w, "data: {\"type\":\"session.idle\",\"properties\":{\"sessionID\":\"ses_1\"}}\n\n")
+			w.(http.Flusher).Flush()
+		}
+		<-r.Context().Done()
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, "test", "model-a")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	client.SetWaitTimeout(200 * time.Millisecond)
+	client.SetPollInterval(10 * time.Millisecond)
+	return client
+}
+
+func TestSummarizeIdleEvent(t *testing.T) {
+	client := newFakeServer(t, &fakeServer{
+		idle:  true,
+		reply: func(int) string { return "  Shipped the release.  " },
+	})
+
+	got, err := client.Summarize(context.Background(), "summarize")
+	if err != nil {
+		t.Fatalf("Summarize: %v", err)
+	}
+	if got != "Shipped the release." {
+		t.Errorf("Summarize = %q, want %q", got, "Shipped the release.")
+	}
+	if model := client.LastModel(); model != "test/model-a" {
+		t.Errorf("LastModel = %q, want %q", model, "test/model-a")
+	}
+}
+
+func TestSummarizeSubscribesBeforeSending(t *testing.T) {
+	f := &fakeServer{
+		idle:  true,
+		reply: func(int) string { return "Done." },
+	}
+	client := newFakeServer(t, f)
+
+	if _, err := client.Summarize(context.Background(), "summarize"); err != nil {
+		t.Fatalf("Summarize: %v", err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.subscribedFirst {
+		t.Error("message sent before the event stream was connected")
+	}
+}
+
+func TestSummarizeSlowGeneration(t *testing.T) {
+	client := newFakeServer(t, &fakeServer{
+		idle:     true,
+		generate: 300 * time.Millisecond,
+		reply:    func(int) string { return "Migrated the database." },
+	})
+	// The wait, not the client's timeout, bounds the generation
+	client.httpClient.Timeout = 50 * time.Millisecond
+	client.SetWaitTimeout(2 * time.Second)
+
+	got, err := client.Summarize(context.Background(), "summarize")
+	if err != nil {
+		t.Fatalf("Summarize: %v", err)
+	}
+	if got != "Migrated the database." {
+		t.Errorf("Summarize = %q, want %q", got, "Migrated the database.")
+	}
+}
+
+func TestSummarizeTimings(t *testing.T) {
+	client := newFakeServer(t, &fakeServer{
+		idle:     true,
+		generate: 100 * time.Millisecond,
+		reply:    func(int) string { return "Done." },
+	})
+	var logs []string
+	client.SetLogger(func(format string, args ...any) {
+		logs = append(logs, fmt.Sprintf(format, args...))
+	})
+
+	if _, err := client.Summarize(context.Background(), "summarize"); err != nil {
+		t.Fatalf("Summarize: %v", err)
+	}
+
+	// Generation happens while the message is sent, so it is timed there
+	for _, line := range logs {
+		if d, ok := strings.CutPrefix(line, "reply generated in "); ok {
+			if took, err := time.ParseDuration(d); err != nil || took < 100*time.Millisecond {
+				t.Errorf("reply generated in %s, want at least 100ms", d)
+			}
+			return
+		}
+	}
+	t.Errorf("no generation timing logged: %q", logs)
+}
+
+func TestSummarizeIdleTimeout(t *testing.T) {
+	// No idle event arrives, but the response is there once the wait ends
+	client := newFakeServer(t, &fakeServer{
+		reply: func(int) string { return "Fixed the build." },
+	})
+
+	got, err := client.Summarize(context.Background(), "summarize")
+	if err != nil {
+		t.Fatalf("Summarize: %v", err)
+	}
+	if got != "Fixed the build." {
+		t.Errorf("Summarize = %q, want %q", got, "Fixed the build.")
+	}
+}
+
+func TestSummarizePollingFallback(t *testing.T) {
+	f := &fakeServer{
+		reply: func(n int) string {
+			if n < 3 {
+				return ""
+			}
+			return "Reviewed two PRs."
+		},
+	}
+	client := newFakeServer(t, f)
+
+	got, err := client.Summarize(context.Background(), "summarize")
+	if err != nil {
+		t.Fatalf("Summarize: %v", err)
+	}
+	if got != "Reviewed two PRs." {
+		t.Errorf("Summarize = %q, want %q", got, "Reviewed two PRs.")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.requests < 3 {
+		t.Errorf("messages requested %d time(s), want at least 3", f.requests)
+	}
+}
+
+func TestSummarizeNoResponse(t *testing.T) {
+	client := newFakeServer(t, &fakeServer{
+		reply: func(int) string { return "" },
+	})
+
+	_, err := client.Summarize(context.Background(), "summarize")
+	if err == nil || !strings.Contains(err.Error(), "no response received from AI") {
+		t.Fatalf("Summarize error = %v, want no response", err)
+	}
+}
+
+func TestProbeIgnoresConnectionCache(t *testing.T) {
+	healthy := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, "test", "model-a")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	client.SetConnectionCache(filepath.Join(t.TempDir(), "connection"))
+
+	if err := client.TestConnection(context.Background()); err != nil {
+		t.Fatalf("TestConnection: %v", err)
+	}
+
+	healthy = false
+	if err := client.TestConnection(context.Background()); err != nil {
+		t.Errorf("TestConnection = %v, want cached success", err)
+	}
+	if err := client.Probe(context.Background()); err == nil {
+		t.Error("Probe succeeded against an unhealthy server")
+	}
+}
+
+func TestNormalizeBaseURL(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "http://127.0.0.1:4096", want: "http://127.0.0.1:4096"},
+		{in: "http://127.0.0.1:4096/", want: "http://127.0.0.1:4096"},
+		{in: "  https://opencode.example.com/  ", want: "https://opencode.example.com"},
+		{in: "127.0.0.1:4096", want: "http://127.0.0.1:4096"},
+		{in: "localhost:4096/", want: "http://localhost:4096"},
+		{in: "http://proxy.example.com/opencode", want: "http://proxy.example.com/opencode"},
+		{in: "http://proxy.example.com/opencode/", want: "http://proxy.example.com/opencode"},
+		{in: "", wantErr: true},
+		{in: "ftp://127.0.0.1:4096", wantErr: true},
+		{in: "http://", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := normalizeBaseURL(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("normalizeBaseURL(%q) = %q, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("normalizeBaseURL(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dividerChar is the character RenderDivider repeats
+var dividerChar = "─"
+
+// emojiText pairs each emoji used in output with a bracketed ASCII label
+var emojiText = []string{
+	"🗑️", "[delete]",
+	"📅", "[date]",
+	"📆", "[week]",
+	"🚀", "[start]",
+	"📋", "[note]",
+	"📄", "[file]",
+	"📊", "[summary]",
+	"📈", "[stats]",
+	"📝", "[edit]",
+	"🤖", "[ai]",
+	"💡", "[tip]",
+	"🎉", "!",
+	"⏱", "[time]",
+	"📌", "[pin]",
+	"🩺", "[doctor]",
+}
+
+// symbolText pairs other non-ASCII symbols used in output with ASCII equivalents
+var symbolText = []string{
+	"✓", "[ok]",
+	"✗", "[error]",
+	"⚠", "[!]",
+	"ℹ", "[i]",
+	"○", "[ ]",
+	"→", "->",
+	"•", "*",
+	"·", "-",
+	"—", "--",
+	"–", "-",
+	"…", "...",
+	"░", ".",
+	"▒", ":",
+	"▓", "+",
+	"█", "#",
+	"─", "-",
+}
+
+// asciiReplacer substitutes emoji and other non-ASCII symbols used in output
+// with bracketed or plain-text equivalents
+var asciiReplacer = strings.NewReplacer(append(append([]string{}, emojiText...), symbolText...)...)
+
+// PreferASCII reports whether the terminal is likely unable to display
+// Unicode, judging by the locale environment variables. An unset locale is
+// treated as Unicode-capable, since many terminals leave it empty.
+func PreferASCII() bool {
+	if runtime.GOOS == "windows" {
+		return false
+	}
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := os.Getenv(key); value != "" {
+			value = strings.ToLower(value)
+			return !strings.Contains(value, "utf-8") && !strings.Contains(value, "utf8")
+		}
+	}
+	return false
+}
+
+// SetASCII switches output to ASCII only: icons become bracketed text, cards
+// use ASCII borders and any emoji in styled text is substituted
+func SetASCII() {
+	IconPending = "[ ]"
+	IconCompleted = "[x]"
+	IconWarning = "[!]"
+	IconInfo = "[i]"
+	IconSuccess = "[ok]"
+	IconError = "[error]"
+	IconArrow = "->"
+	IconBullet = "*"
+	IconPinned = "[pin]"
+	dividerChar = "-"
+
+	for _, style := range []*lipgloss.Style{
+		&TitleStyle, &SubtitleStyle, &HeaderStyle, &PendingItemStyle, &CompletedItemStyle,
+		&SuccessStyle, &ErrorStyle, &WarningStyle, &InfoStyle, &CountBadgeStyle,
+		&PendingBadgeStyle, &CompletedBadgeStyle, &MutedStyle, &SummaryStyle,
+		&AppHeaderStyle, &DividerStyle, &EmptyStateStyle, &PromptStyle,
+	} {
+		*style = style.Transform(asciiReplacer.Replace)
+	}
+
+	for _, card := range []*lipgloss.Style{&CardStyle, &PendingCardStyle, &CompletedCardStyle} {
+		*card = card.Border(lipgloss.ASCIIBorder()).Transform(asciiReplacer.Replace)
+	}
+}
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// RelativeDate returns a friendly label for a date relative to today,
+// such as "today", "yesterday", "3 days ago" or "2 weeks ago"
+func RelativeDate(t time.Time) string {
+	days := daysBetween(t, time.Now())
+
+	switch {
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "yesterday"
+	case days == -1:
+		return "tomorrow"
+	case days < 0:
+		return fmt.Sprintf("in %d days", -days)
+	case days < 7:
+		return fmt.Sprintf("%d days ago", days)
+	case days < 14:
+		return "1 week ago"
+	case days < 30:
+		return fmt.Sprintf("%d weeks ago", days/7)
+	case days < 60:
+		return "1 month ago"
+	case days < 365:
+		return fmt.Sprintf("%d months ago", days/30)
+	case days < 730:
+		return "1 year ago"
+	default:
+		return fmt.Sprintf("%d years ago", days/365)
+	}
+}
+
+// daysBetween returns the number of calendar days from a to b,
+// ignoring the time of day and location of either value
+func daysBetween(a, b time.Time) int {
+	dayA := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	dayB := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(dayB.Sub(dayA).Hours() / 24)
+}
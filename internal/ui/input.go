@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// errEndOfInput is returned when piped input runs out before a required answer
+var errEndOfInput = errors.New("end of input")
+
+// IsTerminal reports whether f is attached to a terminal
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// TerminalHeight returns the number of rows of the terminal f is attached to,
+// or 0 when it is not a terminal or its size is unknown
+func TerminalHeight(f *os.File) int {
+	_, height, err := term.GetSize(f.Fd())
+	if err != nil {
+		return 0
+	}
+	return height
+}
+
+// readLine prints the label and reads one line of piped input, echoing the
+// answer so the transcript reads like an interactive session. It returns
+// io.EOF once the input is exhausted.
+func (p *Prompter) readLine(label string) (string, error) {
+	line, err := p.input.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		fmt.Println(label + ":")
+		return "", err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	fmt.Printf("%s: %s\n", label, line)
+	return line, nil
+}
+
+// readConfirm reads a yes/no answer from piped input. Only "y" or "yes"
+// confirm; anything else, including end of input, declines.
+func (p *Prompter) readConfirm(label string) (bool, error) {
+	answer, err := p.readLine(label + " [y/N]")
+	if err == io.EOF {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// readSelection reads a list choice from piped input, given either as a
+// 1-based number or as the exact item text
+func (p *Prompter) readSelection(label string, items []string) (int, error) {
+	for i, item := range items {
+		fmt.Printf("  %d. %s\n", i+1, item)
+	}
+	answer, err := p.readLine(label)
+	if err == io.EOF {
+		return -1, errEndOfInput
+	}
+	if err != nil {
+		return -1, err
+	}
+	answer = strings.TrimSpace(answer)
+	for i, item := range items {
+		if answer == fmt.Sprint(i+1) || answer == item {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("invalid selection %q", answer)
+}
+
+// newInputReader returns a line reader for stdin when it is not a terminal,
+// so prompts can consume piped input instead of failing
+func newInputReader() *bufio.Reader {
+	if IsTerminal(os.Stdin) {
+		return nil
+	}
+	return bufio.NewReader(os.Stdin)
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// plain is set by SetPlain
+var plain bool
+
+// plainReplacer drops emoji (and the spacing after them) and substitutes
+// other non-ASCII symbols with ASCII equivalents
+var plainReplacer = newPlainReplacer()
+
+// newPlainReplacer builds plainReplacer from the emoji and symbol tables
+func newPlainReplacer() *strings.Replacer {
+	var pairs []string
+	for i := 0; i < len(emojiText); i += 2 {
+		emoji := emojiText[i]
+		pairs = append(pairs, emoji+"  ", "", emoji+" ", "", emoji, "")
+	}
+	return strings.NewReplacer(append(pairs, symbolText...)...)
+}
+
+// IsPlain reports whether plain output is enabled
+func IsPlain() bool {
+	return plain
+}
+
+// SetPlain switches output to a script-friendly form: no colors, emoji,
+// borders or dividers, with icons as short ASCII text
+func SetPlain() {
+	plain = true
+
+	IconPending = "[ ]"
+	IconCompleted = "[x]"
+	IconWarning = "warning:"
+	IconInfo = ""
+	IconSuccess = ""
+	IconError = "error:"
+	IconArrow = "->"
+	IconBullet = "-"
+	IconPinned = "[pin]"
+	dividerChar = ""
+
+	for _, style := range []*lipgloss.Style{
+		&TitleStyle, &SubtitleStyle, &HeaderStyle, &CardStyle, &PendingCardStyle,
+		&CompletedCardStyle, &PendingItemStyle, &CompletedItemStyle, &SuccessStyle,
+		&ErrorStyle, &WarningStyle, &InfoStyle, &CountBadgeStyle, &PendingBadgeStyle,
+		&CompletedBadgeStyle, &MutedStyle, &SummaryStyle, &AppHeaderStyle,
+		&DividerStyle, &EmptyStateStyle, &PromptStyle,
+	} {
+		*style = lipgloss.NewStyle().Transform(plainReplacer.Replace)
+	}
+}
+
+// withIcon prefixes text with an icon, if the current mode has one
+func withIcon(icon, text string) string {
+	if icon == "" {
+		return text
+	}
+	return icon + " " + text
+}
+
+// plainBadge renders a count badge in plain mode
+func plainBadge(count int) string {
+	return fmt.Sprintf("(%d)", count)
+}
@@ -1,26 +1,52 @@
 package ui
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/manifoldco/promptui"
 	"github.com/sandepten/work-obsidian-noter/internal/notes"
 )
 
-// Prompter handles interactive CLI prompts
-type Prompter struct{}
+// Prompter handles interactive CLI prompts. When stdin is not a terminal,
+// prompts read answers line by line from it instead.
+type Prompter struct {
+	groupByProject bool
+	maxItems       int
+	input          *bufio.Reader
+}
 
 // NewPrompter creates a new prompter
 func NewPrompter() *Prompter {
-	return &Prompter{}
+	return &Prompter{input: newInputReader()}
+}
+
+// SetGroupByProject controls whether work item lists are shown under their
+// project headings
+func (p *Prompter) SetGroupByProject(enabled bool) {
+	p.groupByProject = enabled
+}
+
+// SetMaxItems limits how many items each displayed list shows before the rest
+// are summarised as "… and N more". Zero shows every item.
+func (p *Prompter) SetMaxItems(n int) {
+	p.maxItems = n
 }
 
 // ConfirmCompletion asks if a work item was completed
 func (p *Prompter) ConfirmCompletion(item notes.WorkItem) (bool, error) {
+	label := fmt.Sprintf("Did you complete: \"%s\"", item.Text)
+	if p.input != nil {
+		return p.readConfirm(label)
+	}
+
 	prompt := promptui.Prompt{
-		Label:     fmt.Sprintf("Did you complete: \"%s\"", item.Text),
+		Label:     label,
 		IsConfirm: true,
 	}
 
@@ -69,10 +95,54 @@ func (p *Prompter) SelectPendingItems(items []notes.WorkItem) ([]int, error) {
 	return selectedIndices, nil
 }
 
+// SelectItemsToSummarize asks about each item in turn and returns the indices
+// chosen for a summary
+func (p *Prompter) SelectItemsToSummarize(items []notes.WorkItem) ([]int, error) {
+	var selectedIndices []int
+
+	for i, item := range items {
+		include, err := p.ConfirmAction(fmt.Sprintf("Include \"%s\"", item.Text))
+		if err != nil {
+			return selectedIndices, err
+		}
+		if include {
+			selectedIndices = append(selectedIndices, i)
+		}
+	}
+
+	return selectedIndices, nil
+}
+
+// SelectTasksToDelete asks about each item in turn and returns the indices chosen for deletion
+func (p *Prompter) SelectTasksToDelete(items []notes.WorkItem) ([]int, error) {
+	var selectedIndices []int
+
+	for i, item := range items {
+		remove, err := p.ConfirmAction(fmt.Sprintf("Delete \"%s\"", item.Text))
+		if err != nil {
+			return selectedIndices, err
+		}
+		if remove {
+			selectedIndices = append(selectedIndices, i)
+		}
+	}
+
+	return selectedIndices, nil
+}
+
 // PromptForNewItem asks for a new work item
 func (p *Prompter) PromptForNewItem() (string, error) {
+	label := "Enter new work item (leave empty to skip)"
+	if p.input != nil {
+		result, err := p.readLine(label)
+		if err == io.EOF {
+			return "", nil
+		}
+		return result, err
+	}
+
 	prompt := promptui.Prompt{
-		Label: "Enter new work item (leave empty to skip)",
+		Label: label,
 	}
 
 	result, err := prompt.Run()
@@ -88,6 +158,14 @@ func (p *Prompter) PromptForNewItem() (string, error) {
 
 // PromptForTaskInLoop prompts for a task and returns it with a flag indicating if interrupted
 func (p *Prompter) PromptForTaskInLoop(taskNumber int) (string, bool, error) {
+	if p.input != nil {
+		result, err := p.readLine(fmt.Sprintf("Task #%d", taskNumber))
+		if err == io.EOF {
+			return "", true, nil // End of piped input
+		}
+		return strings.TrimSpace(result), false, err
+	}
+
 	label := PromptStyle.Render(fmt.Sprintf("Task #%d", taskNumber))
 	prompt := promptui.Prompt{
 		Label: label,
@@ -106,6 +184,10 @@ func (p *Prompter) PromptForTaskInLoop(taskNumber int) (string, bool, error) {
 
 // ConfirmAction asks for a yes/no confirmation
 func (p *Prompter) ConfirmAction(message string) (bool, error) {
+	if p.input != nil {
+		return p.readConfirm(message)
+	}
+
 	prompt := promptui.Prompt{
 		Label:     message,
 		IsConfirm: true,
@@ -124,6 +206,10 @@ func (p *Prompter) ConfirmAction(message string) (bool, error) {
 
 // SelectFromList allows selecting an item from a list
 func (p *Prompter) SelectFromList(label string, items []string) (int, error) {
+	if p.input != nil {
+		return p.readSelection(label, items)
+	}
+
 	prompt := promptui.Select{
 		Label: label,
 		Items: items,
@@ -146,12 +232,7 @@ func (p *Prompter) DisplayWorkItems(pending, completed []notes.WorkItem) {
 	if len(pending) == 0 {
 		fmt.Println(RenderEmptyState("  No pending items — you're all caught up!"))
 	} else {
-		var pendingItems []string
-		for i, item := range pending {
-			pendingItems = append(pendingItems, RenderPendingItem(i+1, item.Text))
-		}
-		content := strings.Join(pendingItems, "\n")
-		fmt.Println(PendingCardStyle.Render(content))
+		fmt.Println(PendingCardStyle.Render(p.renderItems(pending, RenderPendingItem, true)))
 	}
 
 	// Completed section
@@ -161,12 +242,7 @@ func (p *Prompter) DisplayWorkItems(pending, completed []notes.WorkItem) {
 	if len(completed) == 0 {
 		fmt.Println(RenderEmptyState("  No completed items yet"))
 	} else {
-		var completedItems []string
-		for i, item := range completed {
-			completedItems = append(completedItems, RenderCompletedItem(i+1, item.Text))
-		}
-		content := strings.Join(completedItems, "\n")
-		fmt.Println(CompletedCardStyle.Render(content))
+		fmt.Println(CompletedCardStyle.Render(p.renderItems(completed, RenderCompletedItem, false)))
 	}
 }
 
@@ -179,13 +255,114 @@ func (p *Prompter) DisplayPendingOnly(pending []notes.WorkItem) {
 	if len(pending) == 0 {
 		fmt.Println(RenderEmptyState("  No pending items — you're all caught up!"))
 	} else {
-		var pendingItems []string
-		for i, item := range pending {
-			pendingItems = append(pendingItems, RenderPendingItem(i+1, item.Text))
+		fmt.Println(PendingCardStyle.Render(p.renderItems(pending, RenderPendingItem, true)))
+	}
+}
+
+// renderItems renders numbered items for a card in display order, under
+// project headings when grouping is enabled
+func (p *Prompter) renderItems(items []notes.WorkItem, render func(int, string) string, pinFirst bool) string {
+	order := p.displayOrder(items, pinFirst)
+	// Head the groups unless every item is ungrouped
+	headed := p.groupByProject && slices.ContainsFunc(items, func(item notes.WorkItem) bool {
+		return item.Group != ""
+	})
+
+	var lines []string
+	n := 0
+	for i, index := range order {
+		if p.maxItems > 0 && n == p.maxItems {
+			break
+		}
+		item := items[index]
+		// Head a group only once one of its items fits under the limit
+		if headed && (i == 0 || items[order[i-1]].Group != item.Group) {
+			name := item.Group
+			if name == "" {
+				name = "(no project)"
+			}
+			lines = append(lines, SubtitleStyle.Render(name))
+		}
+		n++
+		lines = append(lines, render(n, itemText(item)))
+	}
+	if hidden := len(items) - n; hidden > 0 {
+		lines = append(lines, MutedStyle.Render(fmt.Sprintf("   … and %d more", hidden)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// displayOrder returns the indices of items in the order lists show them.
+// With grouping enabled, ungrouped items come first, then each project in the
+// order it first appears. Within that, pinned items come first when pinFirst
+// is set, and otherwise items keep their note order.
+func (p *Prompter) displayOrder(items []notes.WorkItem, pinFirst bool) []int {
+	groups := [][]int{nil}
+	slots := map[string]int{"": 0}
+	for i, item := range items {
+		name := ""
+		if p.groupByProject {
+			name = item.Group
+		}
+		slot, ok := slots[name]
+		if !ok {
+			slot = len(groups)
+			slots[name] = slot
+			groups = append(groups, nil)
+		}
+		groups[slot] = append(groups[slot], i)
+	}
+
+	order := make([]int, 0, len(items))
+	for _, group := range groups {
+		if pinFirst {
+			for _, i := range group {
+				if items[i].Pinned {
+					order = append(order, i)
+				}
+			}
+		}
+		for _, i := range group {
+			if !pinFirst || !items[i].Pinned {
+				order = append(order, i)
+			}
+		}
+	}
+	return order
+}
+
+// DisplayOrder returns the indices of pending items in the order lists show
+// them, so numbers typed by the user can be mapped back to items
+func (p *Prompter) DisplayOrder(items []notes.WorkItem) []int {
+	return p.displayOrder(items, true)
+}
+
+// DisplayNumber returns the 1-based number lists show for the pending item
+// items[index], which differs from its place in the note when items are
+// pinned or grouped
+func (p *Prompter) DisplayNumber(items []notes.WorkItem, index int) int {
+	for n, i := range p.DisplayOrder(items) {
+		if i == index {
+			return n + 1
 		}
-		content := strings.Join(pendingItems, "\n")
-		fmt.Println(PendingCardStyle.Render(content))
 	}
+	return index + 1
+}
+
+// itemText returns the display text for a work item, including its pin icon,
+// estimate and, for pending items added before today, how long ago it was added
+func itemText(item notes.WorkItem) string {
+	text := item.Text
+	if item.Pinned {
+		text = IconPinned + " " + text
+	}
+	if item.Estimate > 0 {
+		text += " " + MutedStyle.Render("~"+notes.FormatEstimate(item.Estimate))
+	}
+	if !item.Completed && !item.CreatedAt.IsZero() && daysBetween(item.CreatedAt, time.Now()) > 0 {
+		text += " " + MutedStyle.Render("added "+RelativeDate(item.CreatedAt))
+	}
+	return text
 }
 
 // DisplayMessage shows a message to the user
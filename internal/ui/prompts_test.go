@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sandepten/work-obsidian-noter/internal/notes"
+)
+
+func TestDisplayOrderMatchesRenderedNumbers(t *testing.T) {
+	items := []notes.WorkItem{
+		{Text: "a", Group: "Billing"},
+		{Text: "b"},
+		{Text: "c", Group: "Billing", Pinned: true},
+		{Text: "d", Pinned: true},
+		{Text: "e", Group: "Auth"},
+	}
+
+	tests := []struct {
+		grouped bool
+		want    []string
+	}{
+		{grouped: false, want: []string{"c", "d", "a", "b", "e"}},
+		{grouped: true, want: []string{"d", "b", "c", "a", "e"}},
+	}
+
+	for _, tt := range tests {
+		p := &Prompter{groupByProject: tt.grouped}
+
+		var order []string
+		for _, i := range p.DisplayOrder(items) {
+			order = append(order, items[i].Text)
+		}
+		if !slices.Equal(order, tt.want) {
+			t.Errorf("grouped=%v: DisplayOrder = %q, want %q", tt.grouped, order, tt.want)
+		}
+
+		// The list numbers each item as DisplayNumber does
+		var rendered []string
+		p.renderItems(items, func(n int, text string) string {
+			rendered = append(rendered, text)
+			return ""
+		}, true)
+		for i, item := range items {
+			if line := rendered[p.DisplayNumber(items, i)-1]; !strings.HasSuffix(line, item.Text) {
+				t.Errorf("grouped=%v: item %q numbered %d, but list shows %q there", tt.grouped, item.Text, p.DisplayNumber(items, i), line)
+			}
+		}
+	}
+}
@@ -25,8 +25,8 @@ var (
 	Subtle    = lipgloss.Color("#383838")
 )
 
-// Icons for different states
-const (
+// Icons for different states (replaced with ASCII text by SetASCII)
+var (
 	IconPending   = "○"
 	IconCompleted = "✓"
 	IconAdd       = "+"
@@ -36,6 +36,7 @@ const (
 	IconError     = "✗"
 	IconArrow     = "→"
 	IconBullet    = "•"
+	IconPinned    = "📌"
 )
 
 // Base styles
@@ -160,22 +161,33 @@ func RenderHeader(text string) string {
 
 // RenderSuccess renders a success message with icon
 func RenderSuccess(text string) string {
-	return SuccessStyle.Render(IconSuccess + " " + text)
+	return SuccessStyle.Render(withIcon(IconSuccess, text))
 }
 
 // RenderError renders an error message with icon
 func RenderError(text string) string {
-	return ErrorStyle.Render(IconError + " " + text)
+	return ErrorStyle.Render(withIcon(IconError, text))
 }
 
 // RenderWarning renders a warning message with icon
 func RenderWarning(text string) string {
-	return WarningStyle.Render(IconWarning + " " + text)
+	return WarningStyle.Render(withIcon(IconWarning, text))
 }
 
 // RenderInfo renders an info message with icon
 func RenderInfo(text string) string {
-	return InfoStyle.Render(IconInfo + " " + text)
+	return InfoStyle.Render(withIcon(IconInfo, text))
+}
+
+// SetItemIcons replaces the pending and completed item icons. Empty values
+// keep the current icon.
+func SetItemIcons(pending, completed string) {
+	if pending != "" {
+		IconPending = pending
+	}
+	if completed != "" {
+		IconCompleted = completed
+	}
 }
 
 // RenderPendingItem renders a pending task item
@@ -201,7 +213,7 @@ func RenderEmptyState(text string) string {
 func RenderDivider(width int) string {
 	divider := ""
 	for i := 0; i < width; i++ {
-		divider += "─"
+		divider += dividerChar
 	}
 	return DividerStyle.Render(divider)
 }
@@ -214,5 +226,8 @@ func RenderSummary(title, content string) string {
 
 // RenderBadge renders a count badge
 func RenderBadge(count int, style lipgloss.Style) string {
+	if plain {
+		return plainBadge(count)
+	}
 	return style.Render(fmt.Sprintf(" %d ", count))
 }